@@ -132,6 +132,15 @@ func (d ArrayDict[K, V]) MergeRight(right iterable.Iterable[dict.Entry[K, V]], k
 	return res
 }
 
+// Same returns true if both dictionaries share the same backing array and have the same size.
+// In this case the dictionaries are equal.
+func (d ArrayDict[K, V]) Same(other ArrayDict[K, V]) bool {
+	if len(d.entries) != len(other.entries) {
+		return false
+	}
+	return len(d.entries) == 0 || &d.entries[0] == &other.entries[0]
+}
+
 // String representation of the dictionary
 func (d ArrayDict[K, V]) String() string {
 	return iterable.String[dict.Entry[K, V]](d)
@@ -0,0 +1,189 @@
+package bimap
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/dict/hashdict"
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+)
+
+// ErrConflict is returned when a value is already mapped to a different key.
+var ErrConflict = fmt.Errorf("value is already mapped to a different key")
+
+// BiMap is an immutable bidirectional map with unique keys and unique values.
+type BiMap[K, V any] struct {
+	forward  hashdict.Dict[K, V]
+	backward hashdict.Dict[V, K]
+}
+
+var _ equality.Equal[BiMap[int, int]] = BiMap[int, int]{}
+
+// New creates a new BiMap containing the given entries.
+// If several entries have the same key or the same value, later entries replace earlier ones.
+func New[K, V any](keyEq hash.EqHash[K], valueEq hash.EqHash[V], entries ...dict.Entry[K, V]) BiMap[K, V] {
+	res := BiMap[K, V]{
+		forward:  hashdict.New[K, V](keyEq),
+		backward: hashdict.New[V, K](valueEq),
+	}
+	for _, e := range entries {
+		res = res.ForceSet(e.Key, e.Value)
+	}
+	return res
+}
+
+// FromIterable creates a new BiMap from the given entries.
+// Returns an error wrapping ErrConflict if a value appears with different keys.
+// If a key appears several times, later entries replace earlier ones.
+func FromIterable[K, V any](keyEq hash.EqHash[K], valueEq hash.EqHash[V], entries iterable.Iterable[dict.Entry[K, V]]) (BiMap[K, V], error) {
+	res := New[K, V](keyEq, valueEq)
+	for it := entries.Iterator(); ; {
+		e, ok := it.Next()
+		if !ok {
+			return res, nil
+		}
+		var err error
+		res, err = res.Set(e.Key, e.Value)
+		if err != nil {
+			return res, err
+		}
+	}
+}
+
+// KeyEq returns the EqHash used for keys
+func (m BiMap[K, V]) KeyEq() hash.EqHash[K] {
+	return m.forward.KeyEq()
+}
+
+// ValueEq returns the EqHash used for values
+func (m BiMap[K, V]) ValueEq() hash.EqHash[V] {
+	return m.backward.KeyEq()
+}
+
+// Inverse returns the BiMap with keys and values swapped.
+// This is a constant time operation.
+func (m BiMap[K, V]) Inverse() BiMap[V, K] {
+	return BiMap[V, K]{
+		forward:  m.backward,
+		backward: m.forward,
+	}
+}
+
+// Get the value for the given key
+func (m BiMap[K, V]) Get(key K) (V, bool) {
+	return m.forward.Get(key)
+}
+
+// GetKey returns the key for the given value
+func (m BiMap[K, V]) GetKey(value V) (K, bool) {
+	return m.backward.Get(value)
+}
+
+// ContainsKey checks whether the key is contained in the map
+func (m BiMap[K, V]) ContainsKey(key K) bool {
+	return m.forward.ContainsKey(key)
+}
+
+// ContainsValue checks whether the value is contained in the map
+func (m BiMap[K, V]) ContainsValue(value V) bool {
+	return m.backward.ContainsKey(value)
+}
+
+// Set maps the key to the value.
+// An existing value for the key is replaced.
+// If the value is already mapped to a different key, the original map is returned together with an error wrapping ErrConflict.
+func (m BiMap[K, V]) Set(key K, value V) (BiMap[K, V], error) {
+	if otherKey, ok := m.backward.Get(value); ok && !m.KeyEq().Equal(key, otherKey) {
+		return m, fmt.Errorf("%w: cannot map %+v to %+v, because %+v is mapped to it", ErrConflict, key, value, otherKey)
+	}
+	return m.ForceSet(key, value), nil
+}
+
+// ForceSet maps the key to the value.
+// Existing entries for the key and for the value are removed.
+func (m BiMap[K, V]) ForceSet(key K, value V) BiMap[K, V] {
+	if oldValue, ok := m.forward.Get(key); ok && m.ValueEq().Equal(oldValue, value) {
+		// entry already exists
+		return m
+	}
+	m = m.Remove(key).RemoveValue(value)
+	return BiMap[K, V]{
+		forward:  m.forward.Set(key, value),
+		backward: m.backward.Set(value, key),
+	}
+}
+
+// Remove the entry with the given key
+func (m BiMap[K, V]) Remove(key K) BiMap[K, V] {
+	value, ok := m.forward.Get(key)
+	if !ok {
+		return m
+	}
+	return BiMap[K, V]{
+		forward:  m.forward.Remove(key),
+		backward: m.backward.Remove(value),
+	}
+}
+
+// RemoveValue removes the entry with the given value
+func (m BiMap[K, V]) RemoveValue(value V) BiMap[K, V] {
+	return m.Inverse().Remove(value).Inverse()
+}
+
+// Size returns the number of entries
+func (m BiMap[K, V]) Size() int {
+	return m.forward.Size()
+}
+
+// Iterator over the entries of the map
+func (m BiMap[K, V]) Iterator() iterable.Iterator[dict.Entry[K, V]] {
+	return m.forward.Iterator()
+}
+
+// Keys in the map
+func (m BiMap[K, V]) Keys() iterable.Iterable[K] {
+	return m.forward.Keys()
+}
+
+// Values in the map
+func (m BiMap[K, V]) Values() iterable.Iterable[V] {
+	return m.backward.Keys()
+}
+
+// AsDict returns the mapping from keys to values as a hashdict
+func (m BiMap[K, V]) AsDict() hashdict.Dict[K, V] {
+	return m.forward
+}
+
+// Equal checks whether both maps contain the same entries
+func (m BiMap[K, V]) Equal(other BiMap[K, V]) bool {
+	if m.Size() != other.Size() {
+		return false
+	}
+	for it := iterable.Start[dict.Entry[K, V]](m); it.HasNext(); it.Next() {
+		v, ok := other.Get(it.Current().Key)
+		if !ok || !m.ValueEq().Equal(it.Current().Value, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m BiMap[K, V]) String() string {
+	return m.forward.String()
+}
+
+func (m BiMap[K, V]) checkInvariant() error {
+	if m.forward.Size() != m.backward.Size() {
+		return fmt.Errorf("forward has %d entries, but backward has %d entries", m.forward.Size(), m.backward.Size())
+	}
+	for it := iterable.Start[dict.Entry[K, V]](m.forward); it.HasNext(); it.Next() {
+		k, ok := m.backward.Get(it.Current().Value)
+		if !ok || !m.KeyEq().Equal(k, it.Current().Key) {
+			return fmt.Errorf("entry %v missing in backward map", it.Current())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package bimap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+// model is a simple implementation of a bidirectional map used as a reference
+type model map[int]string
+
+func (m model) keyOf(v string) (int, bool) {
+	for k, v2 := range m {
+		if v == v2 {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+func (m model) copy() model {
+	res := model{}
+	for k, v := range m {
+		res[k] = v
+	}
+	return res
+}
+
+func genKey(t *rapid.T) int {
+	return rapid.IntRange(0, 10).Draw(t, "key").(int)
+}
+
+func genValue(t *rapid.T) string {
+	return rapid.SampledFrom([]string{"a", "b", "c", "d", "e", "f", "g"}).Draw(t, "value").(string)
+}
+
+func assertEqualModel(t *rapid.T, m model, b BiMap[int, string]) {
+	require.NoError(t, b.checkInvariant())
+	require.Equal(t, len(m), b.Size())
+	for k, v := range m {
+		v2, ok := b.Get(k)
+		require.True(t, ok, "key %d missing", k)
+		require.Equal(t, v, v2)
+		k2, ok := b.GetKey(v)
+		require.True(t, ok, "value %s missing", v)
+		require.Equal(t, k, k2)
+	}
+	inv := b.Inverse()
+	require.Equal(t, len(m), inv.Size())
+	for k, v := range m {
+		k2, ok := inv.Get(v)
+		require.True(t, ok)
+		require.Equal(t, k, k2)
+	}
+}
+
+func TestBiMapOperations(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		b := New[int, string](hash.Num[int](), hash.String())
+		m := model{}
+		n := rapid.IntRange(1, 100).Draw(t, "n").(int)
+		for i := 0; i < n; i++ {
+			cmd := rapid.IntRange(0, 4).Draw(t, "cmd").(int)
+			switch cmd {
+			case 0: // set
+				k := genKey(t)
+				v := genValue(t)
+				t.Logf("Set(%d, %s)", k, v)
+				b2, err := b.Set(k, v)
+				if k2, ok := m.keyOf(v); ok && k2 != k {
+					require.True(t, errors.Is(err, ErrConflict))
+					require.True(t, b2.Equal(b))
+				} else {
+					require.NoError(t, err)
+					m[k] = v
+				}
+				b = b2
+			case 1: // force set
+				k := genKey(t)
+				v := genValue(t)
+				t.Logf("ForceSet(%d, %s)", k, v)
+				b = b.ForceSet(k, v)
+				if k2, ok := m.keyOf(v); ok {
+					delete(m, k2)
+				}
+				m[k] = v
+			case 2: // remove
+				k := genKey(t)
+				t.Logf("Remove(%d)", k)
+				b = b.Remove(k)
+				delete(m, k)
+			case 3: // remove value
+				v := genValue(t)
+				t.Logf("RemoveValue(%s)", v)
+				b = b.RemoveValue(v)
+				if k, ok := m.keyOf(v); ok {
+					delete(m, k)
+				}
+			case 4: // inverse twice
+				t.Logf("Inverse().Inverse()")
+				b = b.Inverse().Inverse()
+			}
+			assertEqualModel(t, m, b)
+		}
+	})
+}
+
+func TestBiMapPersistence(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		b := New[int, string](hash.Num[int](), hash.String())
+		m := model{}
+		n := rapid.IntRange(1, 30).Draw(t, "n").(int)
+		for i := 0; i < n; i++ {
+			b = b.ForceSet(genKey(t), genValue(t))
+		}
+		for it := b.Iterator(); ; {
+			e, ok := it.Next()
+			if !ok {
+				break
+			}
+			m[e.Key] = e.Value
+		}
+		old := m.copy()
+		// updates must not modify the original map
+		b2 := b.ForceSet(genKey(t), genValue(t)).Remove(genKey(t)).RemoveValue(genValue(t))
+		require.NoError(t, b2.checkInvariant())
+		assertEqualModel(t, old, b)
+	})
+}
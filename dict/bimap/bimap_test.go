@@ -0,0 +1,38 @@
+package bimap_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/dict/bimap"
+	"github.com/peterzeller/go-fun/hash"
+)
+
+func ExampleBiMap_Set() {
+	m := bimap.New(hash.Num[int](), hash.String(), dict.E(1, "a"), dict.E(2, "b"))
+	m, err := m.Set(3, "a")
+	fmt.Printf("conflict: %v\n", errors.Is(err, bimap.ErrConflict))
+	m, err = m.Set(1, "c")
+	fmt.Printf("err = %v, m = %v\n", err, m)
+	// output:
+	// conflict: true
+	// err = <nil>, m = [1 -> c, 2 -> b]
+}
+
+func ExampleBiMap_ForceSet() {
+	m := bimap.New(hash.Num[int](), hash.String(), dict.E(1, "a"), dict.E(2, "b"))
+	m = m.ForceSet(3, "a")
+	fmt.Printf("m = %v\n", m)
+	// output:
+	// m = [2 -> b, 3 -> a]
+}
+
+func ExampleBiMap_Inverse() {
+	m := bimap.New(hash.Num[int](), hash.String(), dict.E(1, "a"), dict.E(2, "b"))
+	inv := m.Inverse()
+	k, _ := inv.Get("b")
+	fmt.Printf("key of b: %v\n", k)
+	// output:
+	// key of b: 2
+}
@@ -0,0 +1,7 @@
+/*
+Package bimap implements an immutable bidirectional map.
+
+A BiMap maps keys to values such that each value belongs to at most one key.
+Lookups are efficient in both directions, because the map is backed by two hashdicts.
+*/
+package bimap
@@ -20,7 +20,7 @@ type Dict[K, V any] struct {
 func New[K, V any](eq hash.EqHash[K], entries ...dict.Entry[K, V]) Dict[K, V] {
 	var root node[K, V] = empty[K, V]{}
 	for _, e := range entries {
-		root = root.updated0(e.Key, eq.Hash(e.Key), 0, e.Value, eq)
+		root, _ = root.updated0(e.Key, eq.Hash(e.Key), 0, e.Value, eq, nil)
 	}
 	return Dict[K, V]{root, eq}
 }
@@ -28,7 +28,7 @@ func New[K, V any](eq hash.EqHash[K], entries ...dict.Entry[K, V]) Dict[K, V] {
 func FromMap[K comparable, V any](eq hash.EqHash[K], m map[K]V) Dict[K, V] {
 	var root node[K, V] = empty[K, V]{}
 	for k, v := range m {
-		root = root.updated0(k, eq.Hash(k), 0, v, eq)
+		root, _ = root.updated0(k, eq.Hash(k), 0, v, eq, nil)
 	}
 	return Dict[K, V]{root, eq}
 }
@@ -61,7 +61,16 @@ func (d Dict[K, V]) ContainsKey(key K) bool {
 }
 
 func (d Dict[K, V]) Set(key K, value V) Dict[K, V] {
-	newRoot := d.root.updated0(key, d.keyEq.Hash(key), 0, value, d.keyEq)
+	return d.SetEq(key, value, nil)
+}
+
+// SetEq sets the value for the key.
+// If the key already has a value that is equal according to valueEq, the original dictionary is returned.
+func (d Dict[K, V]) SetEq(key K, value V, valueEq equality.Equality[V]) Dict[K, V] {
+	newRoot, changed := d.root.updated0(key, d.keyEq.Hash(key), 0, value, d.keyEq, valueEq)
+	if !changed {
+		return d
+	}
 	return Dict[K, V]{newRoot, d.keyEq}
 }
 
@@ -73,6 +82,63 @@ func (d Dict[K, V]) Remove(key K) Dict[K, V] {
 	return Dict[K, V]{newRoot, d.keyEq}
 }
 
+// Update the entry for a key in a single traversal.
+// The function f is called with the current value and a boolean that is true if the key exists.
+// It returns the new value and a boolean that is false if the key should be removed.
+// If the key does not exist and f returns false, the original dictionary is returned.
+func (d Dict[K, V]) Update(key K, f func(V, bool) (V, bool)) Dict[K, V] {
+	return d.update(key, func(old V, exists bool) (V, updateOp) {
+		v, keep := f(old, exists)
+		if keep {
+			return v, opSet
+		}
+		if exists {
+			return v, opRemove
+		}
+		return v, opNone
+	})
+}
+
+// Upsert sets the value for the key to init if the key does not exist yet and to modify(old) if it does.
+func (d Dict[K, V]) Upsert(key K, init V, modify func(V) V) Dict[K, V] {
+	return d.update(key, func(old V, exists bool) (V, updateOp) {
+		if exists {
+			return modify(old), opSet
+		}
+		return init, opSet
+	})
+}
+
+// GetOrInsert returns the value for the key if it exists.
+// Otherwise, it inserts the given value and returns it together with the updated dictionary.
+// If the key exists, the original dictionary is returned.
+func (d Dict[K, V]) GetOrInsert(key K, value V) (V, Dict[K, V]) {
+	res := value
+	newD := d.update(key, func(old V, exists bool) (V, updateOp) {
+		if exists {
+			res = old
+			return old, opNone
+		}
+		return value, opSet
+	})
+	return res, newD
+}
+
+// SetIfAbsent sets the value for the key if the key does not exist yet.
+// If the key exists, the original dictionary is returned.
+func (d Dict[K, V]) SetIfAbsent(key K, value V) Dict[K, V] {
+	_, res := d.GetOrInsert(key, value)
+	return res
+}
+
+func (d Dict[K, V]) update(key K, f func(V, bool) (V, updateOp)) Dict[K, V] {
+	newRoot, changed := d.root.update0(key, d.keyEq.Hash(key), 0, f, d.keyEq)
+	if !changed {
+		return d
+	}
+	return Dict[K, V]{newRoot, d.keyEq}
+}
+
 // Iterator for the dictionary
 func (d Dict[K, V]) Iterator() iterable.Iterator[dict.Entry[K, V]] {
 	return d.root.iterator()
@@ -88,6 +154,17 @@ func (d Dict[K, V]) Values() iterable.Iterable[V] {
 	return iterable.Map[dict.Entry[K, V], V](d, func(e dict.Entry[K, V]) V { return e.Value })
 }
 
+// First returns some entry of the dictionary.
+// The result is deterministic and matches the first entry returned by the iterator.
+// Returns false if the dictionary is empty.
+func (d Dict[K, V]) First() (dict.Entry[K, V], bool) {
+	e, _ := d.root.first()
+	if e == nil {
+		return zero.Value[dict.Entry[K, V]](), false
+	}
+	return *e, true
+}
+
 // Number of entries in the dictionary
 func (d Dict[K, V]) Size() int {
 	return d.root.size()
@@ -101,6 +178,17 @@ type MergeOpts[K, A, B, C any] struct {
 	Left  func(K, A) (C, bool)
 	Right func(K, B) (C, bool)
 	Both  func(K, A, B) (C, bool)
+	// Optional hints that allow the merge to reuse unchanged parts of the input dictionaries.
+	// The hints are only used when the value types match.
+
+	// LeftUnchanged states that Left keeps all entries and returns the value unchanged.
+	LeftUnchanged bool
+	// RightUnchanged states that Right keeps all entries and returns the value unchanged.
+	RightUnchanged bool
+	// BothLeft states that Both keeps all entries and returns the value from the left side.
+	BothLeft bool
+	// BothRight states that Both keeps all entries and returns the value from the right side.
+	BothRight bool
 }
 
 func (o MergeOpts[K, A, B, C]) intern(eq hash.EqHash[K]) mergeOpts[K, A, B, C] {
@@ -112,6 +200,10 @@ func (o MergeOpts[K, A, B, C]) intern(eq hash.EqHash[K]) mergeOpts[K, A, B, C] {
 		},
 		transformA: o.Left,
 		transformB: o.Right,
+		identityA:  o.LeftUnchanged,
+		identityB:  o.RightUnchanged,
+		bothA:      o.BothLeft,
+		bothB:      o.BothRight,
 	}
 }
 
@@ -176,27 +268,37 @@ func (d Dict[K, V]) MergeAll(other iterable.Iterable[dict.Entry[K, V]], opts Mer
 // Merge the given values into the dictionary.
 // If an entry appears on both sides, the merge function is called to determine the new value
 func (d Dict[K, V]) Merge(other iterable.Iterable[dict.Entry[K, V]], mergeFun func(K, V, V) V) Dict[K, V] {
-	return MergeIterable(d, other, MergeOpts[K, V, V, V]{
-		Left:  func(k K, a V) (V, bool) { return a, true },
-		Right: func(k K, b V) (V, bool) { return b, true },
-		Both:  func(k K, a V, b V) (V, bool) { return mergeFun(k, a, b), true },
-	})
+	return MergeIterable(d, other, unionOpts(mergeFun))
 }
 
 // Merge the given values into the dictionary.
 // If an entry appears on both sides, the value from the left side is used.
 func (d Dict[K, V]) MergeLeft(other iterable.Iterable[dict.Entry[K, V]]) Dict[K, V] {
-	return d.Merge(other, func(k K, v1, v2 V) V {
+	opts := unionOpts(func(k K, v1, v2 V) V {
 		return v1
 	})
+	opts.BothLeft = true
+	return MergeIterable(d, other, opts)
 }
 
 // Merge the given values into the dictionary.
 // If an entry appears on both sides, the value from the left side is used.
 func (d Dict[K, V]) MergeRight(other iterable.Iterable[dict.Entry[K, V]]) Dict[K, V] {
-	return d.Merge(other, func(k K, v1, v2 V) V {
+	opts := unionOpts(func(k K, v1, v2 V) V {
 		return v2
 	})
+	opts.BothRight = true
+	return MergeIterable(d, other, opts)
+}
+
+func unionOpts[K, V any](mergeFun func(K, V, V) V) MergeOpts[K, V, V, V] {
+	return MergeOpts[K, V, V, V]{
+		Left:           func(k K, a V) (V, bool) { return a, true },
+		Right:          func(k K, b V) (V, bool) { return b, true },
+		Both:           func(k K, a V, b V) (V, bool) { return mergeFun(k, a, b), true },
+		LeftUnchanged:  true,
+		RightUnchanged: true,
+	}
 }
 
 func (d Dict[K, V]) checkInvariant() error {
@@ -212,7 +314,7 @@ func (d Dict[K, V]) checkInvariant() error {
 func FilterMap[K, A, B any](d Dict[K, A], f func(K, A) (B, bool)) Dict[K, B] {
 	return Dict[K, B]{
 		keyEq: d.keyEq,
-		root:  filterMap(d.root, 0, d.keyEq, f),
+		root:  filterMap(d.root, 0, d.keyEq, f, nil),
 	}
 }
 
@@ -225,7 +327,7 @@ func Map[K, A, B any](d Dict[K, A], f func(K, A) B) Dict[K, B] {
 		keyEq: d.keyEq,
 		root: filterMap(d.root, 0, d.keyEq, func(key K, value A) (B, bool) {
 			return f(key, value), true
-		}),
+		}, nil),
 	}
 }
 
@@ -233,15 +335,47 @@ func (d Dict[K, V]) Map(f func(K, V) V) Dict[K, V] {
 	return Map(d, f)
 }
 
+// Filter returns a dictionary with the entries that satisfy the condition.
+// If all entries satisfy the condition, the original dictionary is returned.
 func (d Dict[K, V]) Filter(cond func(K, V) bool) Dict[K, V] {
+	newRoot := filterMap[K, V, V](d.root, 0, d.keyEq, func(key K, value V) (V, bool) {
+		return value, cond(key, value)
+	}, equality.Fun[V](func(a, b V) bool {
+		// values are not changed by the filter
+		return true
+	}))
+	if sameNode(d.root, newRoot) {
+		return d
+	}
+	return Dict[K, V]{
+		keyEq: d.keyEq,
+		root:  newRoot,
+	}
+}
+
+// MapEq applies f to all values in the dictionary.
+// Subtrees where all new values are equal to the old values according to valueEq are reused.
+func (d Dict[K, V]) MapEq(f func(K, V) V, valueEq equality.Equality[V]) Dict[K, V] {
+	newRoot := filterMap(d.root, 0, d.keyEq, func(key K, value V) (V, bool) {
+		return f(key, value), true
+	}, valueEq)
+	if sameNode(d.root, newRoot) {
+		return d
+	}
 	return Dict[K, V]{
 		keyEq: d.keyEq,
-		root: filterMap(d.root, 0, d.keyEq, func(key K, value V) (V, bool) {
-			return value, cond(key, value)
-		}),
+		root:  newRoot,
 	}
 }
 
+// Same returns true if both dictionaries share the same underlying structure.
+// In this case the dictionaries are equal.
+// This is a cheap check, so it can be used to detect that an operation did not change the dictionary.
+// If Same returns false, the dictionaries might still be equal.
+func (d Dict[K, V]) Same(other Dict[K, V]) bool {
+	return sameNode(d.root, other.root)
+}
+
 func (d Dict[K, V]) Equal(other Dict[K, V], eq equality.Equality[V]) (res bool) {
 	if d.Size() != other.Size() {
 		return false
@@ -263,3 +397,11 @@ func (d Dict[K, V]) Equal(other Dict[K, V], eq equality.Equality[V]) (res bool)
 		}
 	}
 }
+
+// Equality returns an equality instance for dictionaries, given an equality instance for the values.
+// Keys are compared using the key equality of the dictionaries.
+func Equality[K, V any](eq equality.Equality[V]) equality.Equality[Dict[K, V]] {
+	return equality.Fun[Dict[K, V]](func(a, b Dict[K, V]) bool {
+		return a.Equal(b, eq)
+	})
+}
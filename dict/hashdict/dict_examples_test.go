@@ -169,3 +169,49 @@ func ExampleDict_String() {
 	fmt.Printf("d = %s", d.String())
 	// output: d = [x -> 1, z -> 3, y -> 2]
 }
+
+func ExampleDict_Update() {
+	a := hashdict.New(hash.String(),
+		dict.E("x", 1),
+		dict.E("y", 2),
+	)
+	increment := func(v int, exists bool) (int, bool) {
+		return v + 1, true
+	}
+	b := a.Update("x", increment).Update("z", increment)
+	c := b.Update("y", func(v int, exists bool) (int, bool) {
+		return 0, false
+	})
+	fmt.Printf("b = %v\n", b)
+	fmt.Printf("c = %v\n", c)
+	// output:
+	// b = [x -> 2, z -> 1, y -> 2]
+	// c = [x -> 2, z -> 1]
+}
+
+func ExampleDict_Upsert() {
+	d := hashdict.New[string, int](hash.String())
+	for _, w := range []string{"a", "b", "a", "a"} {
+		d = d.Upsert(w, 1, func(count int) int { return count + 1 })
+	}
+	fmt.Printf("d = %v\n", d)
+	// output: d = [b -> 1, a -> 3]
+}
+
+func ExampleDict_GetOrInsert() {
+	a := hashdict.New(hash.String(), dict.E("x", 1))
+	v1, b := a.GetOrInsert("x", 42)
+	v2, c := b.GetOrInsert("y", 42)
+	fmt.Printf("v1 = %v, b = %v\n", v1, b)
+	fmt.Printf("v2 = %v, c = %v\n", v2, c)
+	// output:
+	// v1 = 1, b = [x -> 1]
+	// v2 = 42, c = [x -> 1, y -> 42]
+}
+
+func ExampleDict_SetIfAbsent() {
+	a := hashdict.New(hash.String(), dict.E("x", 1))
+	b := a.SetIfAbsent("x", 42).SetIfAbsent("y", 42)
+	fmt.Printf("b = %v\n", b)
+	// output: b = [x -> 1, y -> 42]
+}
@@ -433,3 +433,69 @@ func TestRemove(t *testing.T) {
 	require.True(t, e.ContainsKey("a"), "contains 'a'")
 	require.True(t, e.ContainsKey("ba"), "contains 'ba'")
 }
+
+func TestDictUpdate(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		d := genDict().Draw(t, "d").(Dict[key, int])
+		model := arraydict.New[key, int]()
+		for it := d.Iterator(); ; {
+			e, ok := it.Next()
+			if !ok {
+				break
+			}
+			model = model.Set(e.Key, e.Value, keyHash)
+		}
+
+		n := rapid.IntRange(1, 50).Draw(t, "n").(int)
+		for i := 0; i < n; i++ {
+			k := genKey(t)
+			cmd := rapid.IntRange(0, 3).Draw(t, "cmd").(int)
+			old, exists := model.Get(k, keyHash)
+			var d2 Dict[key, int]
+			switch cmd {
+			case 0: // update: increment odd values, remove even values
+				t.Logf("d.Update('%s')", k)
+				d2 = d.Update(k, func(v int, ok bool) (int, bool) {
+					require.Equal(t, exists, ok)
+					require.Equal(t, old, v)
+					return v + 1, !ok || v%2 == 1
+				})
+				if !exists || old%2 == 1 {
+					model = model.Set(k, old+1, keyHash)
+				} else {
+					model, _ = model.Remove(k, keyHash)
+				}
+			case 1: // upsert
+				t.Logf("d.Upsert('%s')", k)
+				d2 = d.Upsert(k, 1, func(v int) int { return v * 2 })
+				if exists {
+					model = model.Set(k, old*2, keyHash)
+				} else {
+					model = model.Set(k, 1, keyHash)
+				}
+			case 2: // get or insert
+				t.Logf("d.GetOrInsert('%s', 7)", k)
+				var v int
+				v, d2 = d.GetOrInsert(k, 7)
+				if exists {
+					require.Equal(t, old, v)
+					require.True(t, sameNode(d.root, d2.root), "dictionary should be unchanged")
+				} else {
+					require.Equal(t, 7, v)
+					model = model.Set(k, 7, keyHash)
+				}
+			case 3: // update without insert
+				t.Logf("d.Update('%s') -> absent", k)
+				d2 = d.Update(k, func(v int, ok bool) (int, bool) {
+					return v, ok
+				})
+				if !exists {
+					require.True(t, sameNode(d.root, d2.root), "dictionary should be unchanged")
+				}
+			}
+			d = d2
+			require.NoError(t, d.checkInvariant())
+			assertDictsEqual(t, model, d)
+		}
+	})
+}
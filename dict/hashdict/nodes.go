@@ -14,8 +14,14 @@ import (
 type node[K, V any] interface {
 	size() int
 	get0(key K, hash int64, level int, eq equality.Equality[K]) (V, bool)
-	updated0(key K, hash int64, level int, value V, eq equality.Equality[K]) node[K, V]
+	// updated0 sets the value for the key.
+	// If the key already has a value that is equal according to valueEq, the node is unchanged and false is returned.
+	// If valueEq is nil, existing values are always replaced.
+	updated0(key K, hash int64, level int, value V, eq equality.Equality[K], valueEq equality.Equality[V]) (node[K, V], bool)
 	removed0(key K, hash int64, level int, eq hash.EqHash[K]) (node[K, V], bool)
+	// update0 calls f with the current value for the key and applies the returned operation.
+	// Returns false if the node is unchanged.
+	update0(key K, hash int64, level int, f func(V, bool) (V, updateOp), eq hash.EqHash[K]) (node[K, V], bool)
 	first() (*dict.Entry[K, V], int64)
 	iterator() iterable.Iterator[dict.Entry[K, V]]
 	checkInvariant(level int, prefix int64, eq hash.EqHash[K]) error
@@ -47,7 +53,7 @@ type trie[K, V any] struct {
 }
 
 var _ node[int, string] = empty[int, string]{}
-var _ node[int, string] = singleton[int, string]{}
+var _ node[int, string] = &singleton[int, string]{}
 var _ node[int, string] = bucket[int, string]{}
 var _ node[int, string] = trie[int, string]{}
 
@@ -55,7 +61,7 @@ func (e empty[K, V]) size() int {
 	return 0
 }
 
-func (e singleton[K, V]) size() int {
+func (e *singleton[K, V]) size() int {
 	return 1
 }
 
@@ -71,7 +77,7 @@ func (e empty[K, V]) get0(key K, hash int64, level int, eq equality.Equality[K])
 	return zero.Value[V](), false
 }
 
-func (e singleton[K, V]) get0(key K, hash int64, level int, eq equality.Equality[K]) (V, bool) {
+func (e *singleton[K, V]) get0(key K, hash int64, level int, eq equality.Equality[K]) (V, bool) {
 	if e.hash == hash && eq.Equal(e.entry.Key, key) {
 		return e.entry.Value, true
 	}
@@ -92,35 +98,39 @@ func (e trie[K, V]) get0(key K, hash int64, level int, eq equality.Equality[K])
 	return zero.Value[V](), false
 }
 
-func (e empty[K, V]) updated0(key K, hash int64, level int, value V, eq equality.Equality[K]) node[K, V] {
-	return singleton[K, V]{
+func (e empty[K, V]) updated0(key K, hash int64, level int, value V, eq equality.Equality[K], valueEq equality.Equality[V]) (node[K, V], bool) {
+	return &singleton[K, V]{
 		hash:  hash,
 		entry: dict.Entry[K, V]{Key: key, Value: value},
-	}
+	}, true
 }
 
-func (e singleton[K, V]) updated0(key K, hash int64, level int, value V, eq equality.Equality[K]) node[K, V] {
+func (e *singleton[K, V]) updated0(key K, hash int64, level int, value V, eq equality.Equality[K], valueEq equality.Equality[V]) (node[K, V], bool) {
 	if hash == e.hash {
 		if eq.Equal(key, e.entry.Key) {
+			if valueEq != nil && valueEq.Equal(e.entry.Value, value) {
+				// same value -> unchanged
+				return e, false
+			}
 			// replace
-			return singleton[K, V]{
+			return &singleton[K, V]{
 				hash:  hash,
 				entry: dict.Entry[K, V]{Key: key, Value: value},
-			}
+			}, true
 		} else {
 			// hash collision -> create bucket
 			return bucket[K, V]{
 				hash:    hash,
 				entries: arraydict.New(e.entry, dict.Entry[K, V]{Key: key, Value: value}),
-			}
+			}, true
 		}
 	} else {
 		// hashes are different, but collision at current level -> create a deeper trie
-		e2 := singleton[K, V]{
+		e2 := &singleton[K, V]{
 			hash:  hash,
 			entry: dict.Entry[K, V]{Key: key, Value: value},
 		}
-		return makeTrie[K, V](e.hash, e, e2.hash, e2, level, eq)
+		return makeTrie[K, V](e.hash, e, e2.hash, e2, level, eq), true
 	}
 }
 
@@ -130,34 +140,43 @@ func index(hash int64, level int) int {
 	return int((uint64(hash) >> level) & 0x1f)
 }
 
-func (e bucket[K, V]) updated0(key K, hash int64, level int, value V, eq equality.Equality[K]) node[K, V] {
+func (e bucket[K, V]) updated0(key K, hash int64, level int, value V, eq equality.Equality[K], valueEq equality.Equality[V]) (node[K, V], bool) {
 	if hash == e.hash {
+		if valueEq != nil {
+			if old, ok := e.entries.Get(key, eq); ok && valueEq.Equal(old, value) {
+				// same value -> unchanged
+				return e, false
+			}
+		}
 		// add to existing bucket
 		newEntries := e.entries.Set(key, value, eq)
 		return bucket[K, V]{
 			hash:    hash,
 			entries: newEntries,
-		}
+		}, true
 	}
 	// if hashes are different, make a new try
-	return makeTrie[K, V](e.hash, e, hash, singleton[K, V]{hash, dict.Entry[K, V]{Key: key, Value: value}}, level, eq)
+	return makeTrie[K, V](e.hash, e, hash, &singleton[K, V]{hash, dict.Entry[K, V]{Key: key, Value: value}}, level, eq), true
 }
 
-func (e trie[K, V]) updated0(key K, hash int64, level int, value V, eq equality.Equality[K]) node[K, V] {
+func (e trie[K, V]) updated0(key K, hash int64, level int, value V, eq equality.Equality[K], valueEq equality.Equality[V]) (node[K, V], bool) {
 	i := index(hash, level)
 	if n, ok := e.children.get(i); ok {
 		// already have a node at this index -> update that node
-		n2 := n.updated0(key, hash, level+5, value, eq)
+		n2, changed := n.updated0(key, hash, level+5, value, eq, valueEq)
+		if !changed {
+			return e, false
+		}
 		return trie[K, V]{
 			children: e.children.set(i, n2),
 			count:    e.count + (n2.size() - n.size()),
-		}
+		}, true
 	}
 	// no node at the given index yet -> add singleton entry
 	return trie[K, V]{
-		children: e.children.set(i, singleton[K, V]{hash, dict.Entry[K, V]{Key: key, Value: value}}),
+		children: e.children.set(i, &singleton[K, V]{hash, dict.Entry[K, V]{Key: key, Value: value}}),
 		count:    e.count + 1,
-	}
+	}, true
 }
 
 // makeTrie creates a trie from two buckets/singletons
@@ -188,7 +207,7 @@ func (e empty[K, V]) removed0(key K, hash int64, level int, eq hash.EqHash[K]) (
 	return e, false
 }
 
-func (e singleton[K, V]) removed0(key K, hash int64, level int, eq hash.EqHash[K]) (node[K, V], bool) {
+func (e *singleton[K, V]) removed0(key K, hash int64, level int, eq hash.EqHash[K]) (node[K, V], bool) {
 	if e.hash == hash && eq.Equal(key, e.entry.Key) {
 		return empty[K, V]{}, true
 	}
@@ -207,7 +226,7 @@ func (e bucket[K, V]) removed0(key K, hash int64, level int, eq hash.EqHash[K])
 	case 0:
 		return empty[K, V]{}, true
 	case 1:
-		return singleton[K, V]{hash: hash, entry: newEntries.First()}, true
+		return &singleton[K, V]{hash: hash, entry: newEntries.First()}, true
 	default:
 		return bucket[K, V]{
 			hash:    hash,
@@ -223,31 +242,117 @@ func (e trie[K, V]) removed0(key K, hash int64, level int, eq hash.EqHash[K]) (n
 		if !changed {
 			return e, false
 		}
-		newChildren := e.children
-		if newC.size() == 0 {
-			newChildren = e.children.remove(index)
-			// check if we can simplify this node even more
-			switch newChildren.size() {
-			case 0:
-				// size 0 -> simplify to empty
-				return empty[K, V]{}, true
-			case 1:
-				// size 1 -> check simplify to singleton
-				firstNode := getFirstNode(newChildren)
-				if firstNode.size() == 1 {
-					singleEntry, singleHash := firstNode.first()
-					return singleton[K, V]{hash: singleHash, entry: *singleEntry}, true
-				}
+		return e.withChild(index, c, newC), true
+	}
+	// index not in array -> unchanged
+	return e, false
+}
+
+// withChild replaces the child oldC at the given index with newC.
+// Empty children are removed and the trie is simplified if possible.
+func (e trie[K, V]) withChild(index int, oldC, newC node[K, V]) node[K, V] {
+	newChildren := e.children
+	if newC.size() == 0 {
+		newChildren = e.children.remove(index)
+		// check if we can simplify this node even more
+		switch newChildren.size() {
+		case 0:
+			// size 0 -> simplify to empty
+			return empty[K, V]{}
+		case 1:
+			// size 1 -> check simplify to singleton
+			firstNode := getFirstNode(newChildren)
+			if firstNode.size() == 1 {
+				singleEntry, singleHash := firstNode.first()
+				return &singleton[K, V]{hash: singleHash, entry: *singleEntry}
 			}
-		} else {
-			newChildren = e.children.set(index, newC)
 		}
+	} else {
+		newChildren = e.children.set(index, newC)
+	}
+	return trie[K, V]{
+		children: newChildren,
+		count:    e.count + (newC.size() - oldC.size()),
+	}
+}
+
+// updateOp is the operation returned by the update function passed to update0
+type updateOp int
+
+const (
+	// opNone leaves the dictionary unchanged
+	opNone updateOp = iota
+	// opSet sets the key to the returned value
+	opSet
+	// opRemove removes the key
+	opRemove
+)
+
+func (e empty[K, V]) update0(key K, hash int64, level int, f func(V, bool) (V, updateOp), eq hash.EqHash[K]) (node[K, V], bool) {
+	if v, op := f(zero.Value[V](), false); op == opSet {
+		return e.updated0(key, hash, level, v, eq, nil)
+	}
+	return e, false
+}
+
+func (e *singleton[K, V]) update0(key K, hash int64, level int, f func(V, bool) (V, updateOp), eq hash.EqHash[K]) (node[K, V], bool) {
+	if hash == e.hash && eq.Equal(key, e.entry.Key) {
+		v, op := f(e.entry.Value, true)
+		switch op {
+		case opSet:
+			return &singleton[K, V]{
+				hash:  hash,
+				entry: dict.Entry[K, V]{Key: key, Value: v},
+			}, true
+		case opRemove:
+			return empty[K, V]{}, true
+		}
+		return e, false
+	}
+	if v, op := f(zero.Value[V](), false); op == opSet {
+		return e.updated0(key, hash, level, v, eq, nil)
+	}
+	return e, false
+}
+
+func (e bucket[K, V]) update0(key K, hash int64, level int, f func(V, bool) (V, updateOp), eq hash.EqHash[K]) (node[K, V], bool) {
+	if hash != e.hash {
+		if v, op := f(zero.Value[V](), false); op == opSet {
+			return e.updated0(key, hash, level, v, eq, nil)
+		}
+		return e, false
+	}
+	old, exists := e.entries.Get(key, eq)
+	v, op := f(old, exists)
+	switch op {
+	case opSet:
+		return bucket[K, V]{
+			hash:    hash,
+			entries: e.entries.Set(key, v, eq),
+		}, true
+	case opRemove:
+		if newEntries, changed := e.entries.Remove(key, eq); changed {
+			return hashAndDictToNode(hash, newEntries), true
+		}
+	}
+	return e, false
+}
+
+func (e trie[K, V]) update0(key K, hash int64, level int, f func(V, bool) (V, updateOp), eq hash.EqHash[K]) (node[K, V], bool) {
+	i := index(hash, level)
+	if c, ok := e.children.get(i); ok {
+		newC, changed := c.update0(key, hash, level+5, f, eq)
+		if !changed {
+			return e, false
+		}
+		return e.withChild(i, c, newC), true
+	}
+	if v, op := f(zero.Value[V](), false); op == opSet {
 		return trie[K, V]{
-			children: newChildren,
-			count:    e.count + (newC.size() - c.size()),
+			children: e.children.set(i, &singleton[K, V]{hash, dict.Entry[K, V]{Key: key, Value: v}}),
+			count:    e.count + 1,
 		}, true
 	}
-	// index not in array -> unchanged
 	return e, false
 }
 
@@ -255,7 +360,7 @@ func (e empty[K, V]) first() (*dict.Entry[K, V], int64) {
 	return nil, 0
 }
 
-func (e singleton[K, V]) first() (*dict.Entry[K, V], int64) {
+func (e *singleton[K, V]) first() (*dict.Entry[K, V], int64) {
 	return &e.entry, e.hash
 }
 
@@ -294,7 +399,7 @@ func (e empty[K, V]) iterator() iterable.Iterator[dict.Entry[K, V]] {
 	})
 }
 
-func (e singleton[K, V]) iterator() iterable.Iterator[dict.Entry[K, V]] {
+func (e *singleton[K, V]) iterator() iterable.Iterator[dict.Entry[K, V]] {
 	init := true
 	return iterable.Fun[dict.Entry[K, V]](func() (dict.Entry[K, V], bool) {
 		if init {
@@ -330,19 +435,59 @@ func (e trie[K, V]) iterator() iterable.Iterator[dict.Entry[K, V]] {
 	})
 }
 
-func filterMap[K, A, B any](dictNode node[K, A], level int, eq hash.EqHash[K], f func(K, A) (B, bool)) node[K, B] {
+// sameNode checks whether two node values refer to the same instance.
+// Only nodes that were obtained by reusing an existing node are considered the same:
+// singletons are compared by pointer, buckets and tries by the identity of their backing arrays.
+// Empty nodes have no state, so all empty nodes of the same type are considered the same.
+func sameNode[K, A, B any](a node[K, A], b node[K, B]) bool {
+	// nodes with different value types can only be the same if A and B are the same type
+	other := any(b)
+	switch x := a.(type) {
+	case empty[K, A]:
+		_, ok := other.(empty[K, A])
+		return ok
+	case *singleton[K, A]:
+		y, ok := other.(*singleton[K, A])
+		return ok && x == y
+	case bucket[K, A]:
+		y, ok := other.(bucket[K, A])
+		return ok && x.hash == y.hash && x.entries.Same(y.entries)
+	case trie[K, A]:
+		y, ok := other.(trie[K, A])
+		return ok && x.children.bitmap == y.children.bitmap && len(x.children.values) == len(y.children.values) &&
+			(len(x.children.values) == 0 || &x.children.values[0] == &y.children.values[0])
+	}
+	return false
+}
+
+// filterMap applies f to all entries of the node.
+// If valueEq is not nil and f keeps all entries with equal values, the original node is returned.
+func filterMap[K, A, B any](dictNode node[K, A], level int, eq hash.EqHash[K], f func(K, A) (B, bool), valueEq equality.Equality[B]) node[K, B] {
 	if f == nil {
 		return empty[K, B]{}
 	}
+	// unchanged checks whether the value is unchanged by f
+	unchanged := func(old A, keep bool, newV B) bool {
+		if !keep || valueEq == nil {
+			return false
+		}
+		oldB, ok := any(old).(B)
+		return ok && valueEq.Equal(oldB, newV)
+	}
 	switch e := dictNode.(type) {
 	case empty[K, A]:
 		return empty[K, B]{}
-	case singleton[K, A]:
+	case *singleton[K, A]:
 		newV, keep := f(e.entry.Key, e.entry.Value)
+		if unchanged(e.entry.Value, keep, newV) {
+			if res, ok := dictNode.(node[K, B]); ok {
+				return res
+			}
+		}
 		if !keep {
 			return empty[K, B]{}
 		}
-		return singleton[K, B]{
+		return &singleton[K, B]{
 			hash: e.hash,
 			entry: dict.Entry[K, B]{
 				Key:   e.entry.Key,
@@ -350,25 +495,31 @@ func filterMap[K, A, B any](dictNode node[K, A], level int, eq hash.EqHash[K], f
 			},
 		}
 	case bucket[K, A]:
-		newEntries := arraydict.FilterMap(e.entries, f)
-		switch newEntries.Size() {
-		case 0:
-			return empty[K, B]{}
-		case 1:
-			first := newEntries.First()
-			return singleton[K, B]{hash: e.hash, entry: first}
-		default:
-			return bucket[K, B]{
-				hash:    e.hash,
-				entries: newEntries,
+		allUnchanged := true
+		newEntries := arraydict.FilterMap(e.entries, func(key K, value A) (B, bool) {
+			newV, keep := f(key, value)
+			allUnchanged = allUnchanged && unchanged(value, keep, newV)
+			return newV, keep
+		})
+		if allUnchanged {
+			if res, ok := dictNode.(node[K, B]); ok {
+				return res
 			}
 		}
+		return hashAndDictToNode(e.hash, newEntries)
 	case trie[K, A]:
+		allUnchanged := valueEq != nil
 		newChildren := sparseArrayFilterMap(e.children, func(_ int, n node[K, A]) (node[K, B], bool) {
 			// recursive call
-			newN := filterMap(n, level+5, eq, f)
+			newN := filterMap(n, level+5, eq, f, valueEq)
+			allUnchanged = allUnchanged && sameNode(n, newN)
 			return newN, newN.size() > 0
 		})
+		if allUnchanged {
+			if res, ok := dictNode.(node[K, B]); ok {
+				return res
+			}
+		}
 		switch newChildren.size() {
 		case 0:
 			return empty[K, B]{}
@@ -395,6 +546,16 @@ type mergeOpts[K, A, B, C any] struct {
 	// if transform functions are nil, the respective entries will be omitted
 	transformA func(K, A) (C, bool)
 	transformB func(K, B) (C, bool)
+	// identityA is true if transformA keeps all entries unchanged
+	identityA bool
+	// identityB is true if transformB keeps all entries unchanged
+	identityB bool
+	// bothA is true if mergeFun always keeps the value from A
+	bothA bool
+	// bothB is true if mergeFun always keeps the value from B
+	bothB bool
+	// preferB is true if nodes from B should be reused when both sides could be reused.
+	preferB bool
 }
 
 func (o mergeOpts[K, A, B, C]) swap() mergeOpts[K, B, A, C] {
@@ -404,7 +565,47 @@ func (o mergeOpts[K, A, B, C]) swap() mergeOpts[K, B, A, C] {
 		mergeFun2:  o.mergeFun,
 		transformA: o.transformB,
 		transformB: o.transformA,
+		identityA:  o.identityB,
+		identityB:  o.identityA,
+		bothA:      o.bothB,
+		bothB:      o.bothA,
+		preferB:    !o.preferB,
+	}
+}
+
+// reuse returns one of the original nodes if the merge result is known to be the same as the original.
+// reuseA and reuseB are the conditions under which nodeA and nodeB can be reused.
+func (o mergeOpts[K, A, B, C]) reuse(nodeA node[K, A], nodeB node[K, B], reuseA, reuseB func() bool) (node[K, C], bool) {
+	if o.preferB {
+		return o.swap().reuse(nodeB, nodeA, reuseB, reuseA)
+	}
+	if res, ok := nodeA.(node[K, C]); ok && reuseA() {
+		return res, true
+	}
+	if res, ok := nodeB.(node[K, C]); ok && reuseB() {
+		return res, true
+	}
+	return nil, false
+}
+
+// transformNodeA applies transformA to all entries in the node.
+// If transformA is known to be the identity, the node is reused.
+func (o mergeOpts[K, A, B, C]) transformNodeA(n node[K, A], level int) node[K, C] {
+	if o.transformA == nil {
+		return empty[K, C]{}
+	}
+	if o.identityA {
+		if res, ok := n.(node[K, C]); ok {
+			return res
+		}
 	}
+	return filterMap(n, level, o.eq, o.transformA, nil)
+}
+
+// transformNodeB applies transformB to all entries in the node.
+// If transformB is known to be the identity, the node is reused.
+func (o mergeOpts[K, A, B, C]) transformNodeB(n node[K, B], level int) node[K, C] {
+	return o.swap().transformNodeA(n, level)
 }
 
 func (o mergeOpts[K, A, B, C]) applyA(key K, a A) (C, bool) {
@@ -421,6 +622,29 @@ func (o mergeOpts[K, A, B, C]) applyB(key K, b B) (C, bool) {
 	return o.transformB(key, b)
 }
 
+// sameChildren checks whether the new children are the same instances as the original children.
+func sameChildren[K, A, C any](origChildren sparseArray[node[K, A]], newChildren sparseArray[node[K, C]]) bool {
+	if origChildren.bitmap != newChildren.bitmap {
+		return false
+	}
+	for i, c := range newChildren.values {
+		if !sameNode(origChildren.values[i], c) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAllKeys checks whether all keys in b are also contained in a
+func containsAllKeys[K, A, B any](a arraydict.ArrayDict[K, A], b arraydict.ArrayDict[K, B], eq hash.EqHash[K]) bool {
+	for it := iterable.Start[dict.Entry[K, B]](b); it.HasNext(); it.Next() {
+		if !a.ContainsKey(it.Current().Key, eq) {
+			return false
+		}
+	}
+	return true
+}
+
 func trieArrayToNode[K, V any](ar sparseArray[node[K, V]]) node[K, V] {
 	if ar.size() == 0 {
 		return empty[K, V]{}
@@ -437,7 +661,7 @@ func hashAndDictToNode[K, V any](hash int64, d arraydict.ArrayDict[K, V]) node[K
 	case 0:
 		return empty[K, V]{}
 	case 1:
-		return singleton[K, V]{
+		return &singleton[K, V]{
 			hash:  hash,
 			entry: d.First(),
 		}
@@ -459,25 +683,41 @@ func merge[K, A, B, C any](nodeA node[K, A], nodeB node[K, B], level int, opt me
 	// trie      |       |           |        | x
 	// all other cases are handled by a recursive call with swapped parameters
 
+	if sameNode(nodeA, nodeB) {
+		// merging a node with itself
+		if res, ok := opt.reuse(nodeA, nodeB, func() bool { return opt.bothA }, func() bool { return opt.bothB }); ok {
+			return res
+		}
+	}
+	if nodeA.size() != nodeB.size() {
+		// prefer the larger side if its entries are kept unchanged (like in a union)
+		// otherwise prefer the smaller side (like in an intersection)
+		if nodeB.size() > nodeA.size() {
+			opt.preferB = opt.identityB
+		} else {
+			opt.preferB = !opt.identityA
+		}
+	}
+
 	switch a := nodeA.(type) {
 	case empty[K, A]:
-		if opt.transformB == nil {
-			return empty[K, C]{}
-		}
-		return filterMap(nodeB, level, opt.eq, opt.transformB)
-	case singleton[K, A]:
+		return opt.transformNodeB(nodeB, level)
+	case *singleton[K, A]:
 		switch b := nodeB.(type) {
 		case empty[K, B]:
 			return merge(nodeB, nodeA, level, opt.swap())
-		case singleton[K, B]:
+		case *singleton[K, B]:
 
 			if a.hash == b.hash {
 				// hash collision
 				if opt.eq.Equal(a.entry.Key, b.entry.Key) {
 					// same key -> merge
+					if res, ok := opt.reuse(nodeA, nodeB, func() bool { return opt.bothA }, func() bool { return opt.bothB }); ok {
+						return res
+					}
 					merged, keep := opt.mergeFun(a.entry.Key, a.entry.Value, b.entry.Value)
 					if keep {
-						return singleton[K, C]{hash: a.hash, entry: dict.Entry[K, C]{Key: a.entry.Key, Value: merged}}
+						return &singleton[K, C]{hash: a.hash, entry: dict.Entry[K, C]{Key: a.entry.Key, Value: merged}}
 					}
 					return empty[K, C]{}
 				}
@@ -487,8 +727,8 @@ func merge[K, A, B, C any](nodeA node[K, A], nodeB node[K, B], level int, opt me
 			if keepA && keepB {
 				if a.hash != b.hash {
 					// different hashes -> create trie
-					return makeTrie[K, C](a.hash, singleton[K, C]{hash: a.hash, entry: dict.Entry[K, C]{Key: a.entry.Key, Value: aNew}},
-						b.hash, singleton[K, C]{hash: b.hash, entry: dict.Entry[K, C]{Key: b.entry.Key, Value: bNew}}, level, opt.eq)
+					return makeTrie[K, C](a.hash, &singleton[K, C]{hash: a.hash, entry: dict.Entry[K, C]{Key: a.entry.Key, Value: aNew}},
+						b.hash, &singleton[K, C]{hash: b.hash, entry: dict.Entry[K, C]{Key: b.entry.Key, Value: bNew}}, level, opt.eq)
 				}
 				// same hashes -> create bucket
 				return bucket[K, C]{
@@ -499,41 +739,51 @@ func merge[K, A, B, C any](nodeA node[K, A], nodeB node[K, B], level int, opt me
 				}
 			}
 			if keepA {
-				return singleton[K, C]{hash: a.hash, entry: dict.Entry[K, C]{Key: a.entry.Key, Value: aNew}}
+				if res, ok := nodeA.(node[K, C]); ok && opt.identityA {
+					return res
+				}
+				return &singleton[K, C]{hash: a.hash, entry: dict.Entry[K, C]{Key: a.entry.Key, Value: aNew}}
 			}
 			if keepB {
-				return singleton[K, C]{hash: b.hash, entry: dict.Entry[K, C]{Key: b.entry.Key, Value: bNew}}
+				if res, ok := nodeB.(node[K, C]); ok && opt.identityB {
+					return res
+				}
+				return &singleton[K, C]{hash: b.hash, entry: dict.Entry[K, C]{Key: b.entry.Key, Value: bNew}}
 			}
 			return empty[K, C]{}
 		case bucket[K, B]:
 			if a.hash == b.hash {
 				// hash-collision -> update bucket
+				aInB := b.entries.ContainsKey(a.entry.Key, opt.eq)
+				if res, ok := nodeB.(node[K, C]); ok && opt.identityB {
+					if (aInB && opt.bothB) || (!aInB && opt.transformA == nil) {
+						// bucket b is unchanged
+						return res
+					}
+				}
 				newEntries := arraydict.FilterMap(b.entries, func(key K, bv B) (C, bool) {
 					if opt.eq.Equal(key, a.entry.Key) {
 						return opt.mergeFun(key, a.entry.Value, bv)
 					}
 					return opt.applyB(key, bv)
 				})
-				if opt.transformA != nil {
-					if !newEntries.ContainsKey(a.entry.Key, opt.eq) {
-						if newVal, keep := opt.applyA(a.entry.Key, a.entry.Value); keep {
-							newEntries = newEntries.Set(a.entry.Key, newVal, opt.eq)
-						}
+				if !aInB {
+					if newVal, keep := opt.applyA(a.entry.Key, a.entry.Value); keep {
+						newEntries = newEntries.Set(a.entry.Key, newVal, opt.eq)
 					}
 				}
-				return bucket[K, C]{
-					hash:    a.hash,
-					entries: newEntries,
-				}
+				return hashAndDictToNode(a.hash, newEntries)
 			}
 			// different hashes -> create trie
-			aNew, keepA := opt.applyA(a.entry.Key, a.entry.Value)
-			bNew := filterMap[K, B](b, level, opt.eq, opt.transformB)
-			if !keepA {
+			aNew := opt.transformNodeA(nodeA, level)
+			bNew := opt.transformNodeB(nodeB, level)
+			if aNew.size() == 0 {
 				return bNew
 			}
-			return makeTrie[K, C](a.hash, singleton[K, C]{hash: a.hash, entry: dict.Entry[K, C]{Key: a.entry.Key, Value: aNew}},
-				b.hash, bNew, level, opt.eq)
+			if bNew.size() == 0 {
+				return aNew
+			}
+			return makeTrie[K, C](a.hash, aNew, b.hash, bNew, level, opt.eq)
 		case trie[K, B]:
 			aIndex := index(a.hash, level)
 			updated := false
@@ -543,36 +793,39 @@ func merge[K, A, B, C any](nodeA node[K, A], nodeB node[K, B], level int, opt me
 					newNode = merge(nodeA, n, level+5, opt)
 					updated = true
 				} else {
-					if opt.transformB == nil {
-						newNode = empty[K, C]{}
-					} else {
-						newNode = filterMap(n, level+5, opt.eq, opt.transformB)
-					}
+					newNode = opt.transformNodeB(n, level+5)
 				}
 				return newNode, newNode.size() > 0
 			})
 			if !updated {
-				if newV, ok := opt.applyA(a.entry.Key, a.entry.Value); ok {
-					bNew = bNew.set(aIndex, singleton[K, C]{
-						hash: a.hash,
-						entry: dict.Entry[K, C]{
-							Key:   a.entry.Key,
-							Value: newV,
-						},
-					})
+				if aNew := opt.transformNodeA(nodeA, level); aNew.size() > 0 {
+					bNew = bNew.set(aIndex, aNew)
 				}
 			}
+			if res, ok := nodeB.(node[K, C]); ok && sameChildren(b.children, bNew) {
+				return res
+			}
 			return trieArrayToNode(bNew)
 		}
 	case bucket[K, A]:
 		switch b := nodeB.(type) {
 		case empty[K, B]:
 			return merge(nodeB, nodeA, level, opt.swap())
-		case singleton[K, B]:
+		case *singleton[K, B]:
 			return merge(nodeB, nodeA, level, opt.swap())
 		case bucket[K, B]:
 			if a.hash == b.hash {
 				// hash-collision -> update bucket
+				if res, ok := opt.reuse(nodeA, nodeB,
+					func() bool {
+						return opt.identityA && opt.bothA && (opt.transformB == nil || containsAllKeys(a.entries, b.entries, opt.eq))
+					},
+					func() bool {
+						return opt.identityB && opt.bothB && (opt.transformA == nil || containsAllKeys(b.entries, a.entries, opt.eq))
+					}); ok {
+					// one of the buckets is unchanged
+					return res
+				}
 				newDict := arraydict.FilterMap(a.entries, func(key K, av A) (C, bool) {
 					if bv, ok := b.entries.Get(key, opt.eq); ok {
 						return opt.mergeFun(key, av, bv)
@@ -582,7 +835,7 @@ func merge[K, A, B, C any](nodeA node[K, A], nodeB node[K, B], level int, opt me
 				if opt.transformB != nil {
 					// add entries appearing in b but not in a
 					for it := iterable.Start[dict.Entry[K, B]](b.entries); it.HasNext(); it.Next() {
-						if !newDict.ContainsKey(it.Current().Key, opt.eq) {
+						if !a.entries.ContainsKey(it.Current().Key, opt.eq) {
 							newV, keep := opt.transformB(it.Current().Key, it.Current().Value)
 							if keep {
 								newDict = newDict.Set(it.Current().Key, newV, opt.eq)
@@ -594,8 +847,14 @@ func merge[K, A, B, C any](nodeA node[K, A], nodeB node[K, B], level int, opt me
 				return hashAndDictToNode(a.hash, newDict)
 			}
 			// different hashes -> create trie
-			aNew := filterMap[K, A](a, level, opt.eq, opt.transformA)
-			bNew := filterMap[K, B](b, level, opt.eq, opt.transformB)
+			aNew := opt.transformNodeA(nodeA, level)
+			bNew := opt.transformNodeB(nodeB, level)
+			if aNew.size() == 0 {
+				return bNew
+			}
+			if bNew.size() == 0 {
+				return aNew
+			}
 			return makeTrie[K](a.hash, aNew, b.hash, bNew, level, opt.eq)
 		case trie[K, B]:
 			aIndex := index(a.hash, level)
@@ -606,16 +865,17 @@ func merge[K, A, B, C any](nodeA node[K, A], nodeB node[K, B], level int, opt me
 					newNode = merge(nodeA, n, level+5, opt)
 					merged = true
 				} else {
-					if opt.transformB == nil {
-						newNode = empty[K, C]{}
-					} else {
-						newNode = filterMap(n, level+5, opt.eq, opt.transformB)
-					}
+					newNode = opt.transformNodeB(n, level+5)
 				}
 				return newNode, newNode.size() > 0
 			})
-			if !merged && opt.transformA != nil {
-				bNew = bNew.set(aIndex, filterMap(nodeA, level, opt.eq, opt.transformA))
+			if !merged {
+				if aNew := opt.transformNodeA(nodeA, level); aNew.size() > 0 {
+					bNew = bNew.set(aIndex, aNew)
+				}
+			}
+			if res, ok := nodeB.(node[K, C]); ok && sameChildren(b.children, bNew) {
+				return res
 			}
 			return trieArrayToNode(bNew)
 		}
@@ -624,7 +884,7 @@ func merge[K, A, B, C any](nodeA node[K, A], nodeB node[K, B], level int, opt me
 		switch b := nodeB.(type) {
 		case empty[K, B]:
 			return merge(nodeB, nodeA, level, opt.swap())
-		case singleton[K, B]:
+		case *singleton[K, B]:
 			return merge(nodeB, nodeA, level, opt.swap())
 		case bucket[K, B]:
 			return merge(nodeB, nodeA, level, opt.swap())
@@ -636,32 +896,25 @@ func merge[K, A, B, C any](nodeA node[K, A], nodeB node[K, B], level int, opt me
 					if bChild, ok := b.children.get(i); ok {
 						merged = merge(aChild, bChild, level+5, opt)
 					} else {
-						if opt.transformA != nil {
-							merged = filterMap(aChild, level+5, opt.eq, opt.transformA)
-						}
+						merged = opt.transformNodeA(aChild, level+5)
 					}
 				} else {
 					if bChild, ok := b.children.get(i); ok {
-						if opt.transformB != nil {
-							merged = filterMap(bChild, level+5, opt.eq, opt.transformB)
-						}
+						merged = opt.transformNodeB(bChild, level+5)
 					}
 				}
 				if merged != nil && merged.size() > 0 {
 					newEntries = append(newEntries, dict.Entry[int, node[K, C]]{Key: i, Value: merged})
 				}
 			}
-			if len(newEntries) == 0 {
-				return empty[K, C]{}
-			}
-			count := 0
-			for _, e := range newEntries {
-				count += e.Value.size()
-			}
-			return trie[K, C]{
-				children: newSparseArraySorted(newEntries...),
-				count:    count,
+			newChildren := newSparseArraySorted(newEntries...)
+			// reuse the original nodes if nothing changed
+			if res, ok := opt.reuse(nodeA, nodeB,
+				func() bool { return sameChildren(a.children, newChildren) },
+				func() bool { return sameChildren(b.children, newChildren) }); ok {
+				return res
 			}
+			return trieArrayToNode(newChildren)
 		}
 	}
 	panic(fmt.Errorf("unhandled case %+v, %+v", nodeA, nodeB))
@@ -671,7 +924,7 @@ func (e empty[K, V]) checkInvariant(level int, prefix int64, eq hash.EqHash[K])
 	return nil
 }
 
-func (e singleton[K, V]) checkInvariant(level int, prefix int64, eq hash.EqHash[K]) error {
+func (e *singleton[K, V]) checkInvariant(level int, prefix int64, eq hash.EqHash[K]) error {
 	if e.hash != eq.Hash((e.entry.Key)) {
 		return fmt.Errorf("wrong hash in singleton")
 	}
@@ -720,7 +973,7 @@ func (e empty[K, V]) String() string {
 	return "empty"
 }
 
-func (e singleton[K, V]) String() string {
+func (e *singleton[K, V]) String() string {
 	return fmt.Sprintf("singleton(%b)[%+v]", e.hash, e.entry)
 }
 
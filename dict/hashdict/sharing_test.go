@@ -0,0 +1,89 @@
+package hashdict
+
+import (
+	"testing"
+
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+func TestSharingSetEq(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		d := genDict().Draw(t, "d").(Dict[key, int])
+		for _, k := range iterable.ToSlice(d.Keys()) {
+			v, _ := d.Get(k)
+			require.True(t, d.Same(d.SetEq(k, v, equality.Default[int]())), "set %v to same value", k)
+			require.True(t, d.Same(d.SetIfAbsent(k, v+1)), "set %v if absent", k)
+			d2 := d.SetEq(k, v+1, equality.Default[int]())
+			require.False(t, d.Same(d2), "set %v to new value", k)
+			require.NoError(t, d2.checkInvariant())
+		}
+	})
+}
+
+func TestSharingRemove(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		d := genDict().Draw(t, "d").(Dict[key, int])
+		k := genKey(t)
+		if !d.ContainsKey(k) {
+			require.True(t, d.Same(d.Remove(k)))
+		}
+	})
+}
+
+func TestSharingFilter(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		d := genDict().Draw(t, "d").(Dict[key, int])
+		require.True(t, d.Same(d.Filter(func(k key, v int) bool { return true })))
+		require.True(t, d.Same(d.MapEq(func(k key, v int) int { return v }, equality.Default[int]())))
+	})
+}
+
+func TestSharingMerge(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		d := genDict().Draw(t, "d").(Dict[key, int])
+		require.True(t, d.Same(d.MergeLeft(d)))
+		require.True(t, d.Same(d.MergeRight(d)))
+
+		limit := rapid.IntRange(0, 10).Draw(t, "limit").(int)
+		sub := d.Filter(func(k key, v int) bool { return v < limit })
+		require.NoError(t, sub.checkInvariant())
+		require.True(t, d.Same(d.MergeLeft(sub)), "merge with subset")
+		require.True(t, d.Same(sub.MergeRight(d)), "merge subset with superset")
+		require.True(t, d.Same(d.MergeLeft(New[key, int](keyHash))), "merge with empty")
+	})
+}
+
+func TestMergeHints(t *testing.T) {
+	keep := func(k key, v int) (int, bool) { return v, true }
+	left := func(k key, a, b int) (int, bool) { return a, true }
+	rapid.Check(t, func(t *rapid.T) {
+		a := genDict().Draw(t, "a").(Dict[key, int])
+		b := genDict().Draw(t, "b").(Dict[key, int])
+		useLeft := rapid.Bool().Draw(t, "useLeft").(bool)
+		useRight := rapid.Bool().Draw(t, "useRight").(bool)
+		opts := MergeOpts[key, int, int, int]{Both: left}
+		if useLeft {
+			opts.Left = keep
+		}
+		if useRight {
+			opts.Right = keep
+		}
+		expected := Merge(a, b, opts)
+		require.NoError(t, expected.checkInvariant())
+		opts.LeftUnchanged = useLeft
+		opts.RightUnchanged = useRight
+		opts.BothLeft = true
+		actual := Merge(a, b, opts)
+		require.NoError(t, actual.checkInvariant())
+		require.Equal(t, expected.Size(), actual.Size(), "expected %v but got %v", expected, actual)
+		for it := iterable.Start[dict.Entry[key, int]](expected); it.HasNext(); it.Next() {
+			v, ok := actual.Get(it.Current().Key)
+			require.True(t, ok, "missing key %v in %v", it.Current().Key, actual)
+			require.Equal(t, it.Current().Value, v)
+		}
+	})
+}
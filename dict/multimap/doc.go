@@ -0,0 +1,5 @@
+/*
+Package multimap implements an immutable multimap, which maps each key to a set of values.
+It is implemented on top of hashdict and hashset.
+*/
+package multimap
@@ -0,0 +1,184 @@
+package multimap
+
+import (
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/dict/hashdict"
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/set/hashset"
+)
+
+// Multimap maps keys to sets of values.
+// Keys without values are not stored, so a key is contained in the multimap if and only if it has at least one value.
+type Multimap[K, V any] struct {
+	dict    hashdict.Dict[K, hashset.Set[V]]
+	valueEq hash.EqHash[V]
+	// number of key-value pairs
+	size int
+}
+
+var _ equality.Equal[Multimap[int, int]] = Multimap[int, int]{}
+
+// New creates a new multimap containing the given key-value pairs.
+func New[K, V any](keyEq hash.EqHash[K], valueEq hash.EqHash[V], entries ...dict.Entry[K, V]) Multimap[K, V] {
+	res := Multimap[K, V]{
+		dict:    hashdict.New[K, hashset.Set[V]](keyEq),
+		valueEq: valueEq,
+	}
+	for _, e := range entries {
+		res = res.Add(e.Key, e.Value)
+	}
+	return res
+}
+
+// KeyEq returns the EqHash used for keys
+func (m Multimap[K, V]) KeyEq() hash.EqHash[K] {
+	return m.dict.KeyEq()
+}
+
+// ValueEq returns the EqHash used for values
+func (m Multimap[K, V]) ValueEq() hash.EqHash[V] {
+	return m.valueEq
+}
+
+// Get returns the set of values for the given key.
+// Returns an empty set if the key is not contained in the multimap.
+func (m Multimap[K, V]) Get(key K) hashset.Set[V] {
+	if s, ok := m.dict.Get(key); ok {
+		return s
+	}
+	return hashset.New(m.valueEq)
+}
+
+// ContainsKey checks whether the key has at least one value.
+func (m Multimap[K, V]) ContainsKey(key K) bool {
+	return m.dict.ContainsKey(key)
+}
+
+// Contains checks whether the given key-value pair is contained in the multimap.
+func (m Multimap[K, V]) Contains(key K, value V) bool {
+	if s, ok := m.dict.Get(key); ok {
+		return s.Contains(value)
+	}
+	return false
+}
+
+// Add a key-value pair to the multimap.
+// If the pair already exists, the original multimap is returned.
+func (m Multimap[K, V]) Add(key K, value V) Multimap[K, V] {
+	return m.AddAll(key, value)
+}
+
+// AddAll adds the given values for the key.
+func (m Multimap[K, V]) AddAll(key K, values ...V) Multimap[K, V] {
+	return m.updateValues(key, func(s hashset.Set[V]) hashset.Set[V] {
+		return s.Add(values...)
+	})
+}
+
+// Remove a key-value pair from the multimap.
+// If the pair does not exist, the original multimap is returned.
+func (m Multimap[K, V]) Remove(key K, value V) Multimap[K, V] {
+	return m.updateValues(key, func(s hashset.Set[V]) hashset.Set[V] {
+		return s.Remove(value)
+	})
+}
+
+// RemoveKey removes all values for the given key.
+func (m Multimap[K, V]) RemoveKey(key K) Multimap[K, V] {
+	return m.updateValues(key, func(s hashset.Set[V]) hashset.Set[V] {
+		return hashset.New(m.valueEq)
+	})
+}
+
+// SetValues replaces the values for the given key.
+func (m Multimap[K, V]) SetValues(key K, values hashset.Set[V]) Multimap[K, V] {
+	return m.updateValues(key, func(s hashset.Set[V]) hashset.Set[V] {
+		return values
+	})
+}
+
+// updateValues updates the set of values for a key and removes keys with empty sets.
+func (m Multimap[K, V]) updateValues(key K, f func(hashset.Set[V]) hashset.Set[V]) Multimap[K, V] {
+	old, exists := m.dict.Get(key)
+	if !exists {
+		old = hashset.New(m.valueEq)
+	}
+	s := f(old)
+	if s.Same(old) || (!exists && s.Size() == 0) {
+		return m
+	}
+	var newDict hashdict.Dict[K, hashset.Set[V]]
+	if s.Size() > 0 {
+		newDict = m.dict.Set(key, s)
+	} else {
+		newDict = m.dict.Remove(key)
+	}
+	return Multimap[K, V]{
+		dict:    newDict,
+		valueEq: m.valueEq,
+		size:    m.size + s.Size() - old.Size(),
+	}
+}
+
+// Size returns the number of key-value pairs in the multimap.
+func (m Multimap[K, V]) Size() int {
+	return m.size
+}
+
+// KeyCount returns the number of distinct keys in the multimap.
+func (m Multimap[K, V]) KeyCount() int {
+	return m.dict.Size()
+}
+
+// Keys returns the keys that have at least one value.
+func (m Multimap[K, V]) Keys() iterable.Iterable[K] {
+	return m.dict.Keys()
+}
+
+// AsDict returns the underlying dictionary from keys to the non-empty sets of values.
+func (m Multimap[K, V]) AsDict() hashdict.Dict[K, hashset.Set[V]] {
+	return m.dict
+}
+
+// Iterator over all key-value pairs
+func (m Multimap[K, V]) Iterator() iterable.Iterator[dict.Entry[K, V]] {
+	return iterable.FlatMap[dict.Entry[K, hashset.Set[V]], dict.Entry[K, V]](m.dict,
+		func(e dict.Entry[K, hashset.Set[V]]) iterable.Iterable[dict.Entry[K, V]] {
+			return iterable.Map[V](e.Value, func(v V) dict.Entry[K, V] {
+				return dict.Entry[K, V]{Key: e.Key, Value: v}
+			})
+		}).Iterator()
+}
+
+// Inverse returns a multimap with keys and values swapped.
+func (m Multimap[K, V]) Inverse() Multimap[V, K] {
+	res := New[V, K](m.valueEq, m.KeyEq())
+	for it := m.Iterator(); ; {
+		e, ok := it.Next()
+		if !ok {
+			break
+		}
+		res = res.Add(e.Value, e.Key)
+	}
+	return res
+}
+
+// Equal checks whether both multimaps contain the same key-value pairs.
+func (m Multimap[K, V]) Equal(other Multimap[K, V]) bool {
+	if m.size != other.size || m.KeyCount() != other.KeyCount() {
+		return false
+	}
+	for it := iterable.Start[dict.Entry[K, hashset.Set[V]]](m.dict); it.HasNext(); it.Next() {
+		otherValues, ok := other.dict.Get(it.Current().Key)
+		if !ok || !it.Current().Value.Equal(otherValues) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Multimap[K, V]) String() string {
+	return m.dict.String()
+}
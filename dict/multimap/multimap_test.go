@@ -0,0 +1,95 @@
+package multimap_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/dict/multimap"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleMultimap_Add() {
+	m := multimap.New[string, int](hash.String(), hash.Num[int]())
+	m = m.Add("a", 1).Add("a", 2).Add("b", 3).Add("a", 1)
+	fmt.Printf("a -> %v\n", m.Get("a"))
+	fmt.Printf("b -> %v\n", m.Get("b"))
+	fmt.Printf("c -> %v\n", m.Get("c"))
+	fmt.Printf("size = %d, keys = %d\n", m.Size(), m.KeyCount())
+	// output:
+	// a -> [1, 2]
+	// b -> [3]
+	// c -> []
+	// size = 3, keys = 2
+}
+
+func ExampleMultimap_Remove() {
+	m := multimap.New(hash.String(), hash.Num[int](),
+		dict.E("a", 1), dict.E("a", 2), dict.E("b", 3))
+	m = m.Remove("a", 1).Remove("b", 3)
+	fmt.Printf("%v\n", m)
+	fmt.Printf("contains b: %v\n", m.ContainsKey("b"))
+	// output:
+	// [a -> [2]]
+	// contains b: false
+}
+
+func ExampleMultimap_Inverse() {
+	m := multimap.New(hash.String(), hash.Num[int](),
+		dict.E("a", 1), dict.E("a", 2), dict.E("b", 2))
+	inv := m.Inverse()
+	fmt.Printf("1 -> %v\n", inv.Get(1))
+	fmt.Printf("2 -> %v\n", inv.Get(2))
+	// output:
+	// 1 -> [a]
+	// 2 -> [b, a]
+}
+
+func TestMultimapModel(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		m := multimap.New[int, int](hash.Num[int](), hash.Num[int]())
+		model := map[int]map[int]bool{}
+		for i := 0; i < 200; i++ {
+			k := r.Intn(10)
+			v := r.Intn(10)
+			switch r.Intn(4) {
+			case 0, 1:
+				m = m.Add(k, v)
+				if model[k] == nil {
+					model[k] = map[int]bool{}
+				}
+				model[k][v] = true
+			case 2:
+				m2 := m.Remove(k, v)
+				if !model[k][v] {
+					require.True(t, m.AsDict().Same(m2.AsDict()), "removing absent pair should not change the multimap")
+				}
+				m = m2
+				delete(model[k], v)
+				if len(model[k]) == 0 {
+					delete(model, k)
+				}
+			case 3:
+				m = m.RemoveKey(k)
+				delete(model, k)
+			}
+			size := 0
+			for k, vs := range model {
+				size += len(vs)
+				require.Equal(t, len(vs), m.Get(k).Size())
+				for v := range vs {
+					require.True(t, m.Contains(k, v))
+				}
+			}
+			require.Equal(t, size, m.Size())
+			require.Equal(t, len(model), m.KeyCount())
+			require.Equal(t, size, iterable.Length[dict.Entry[int, int]](m))
+			require.Equal(t, size, m.Inverse().Size())
+			require.True(t, m.Equal(m.Inverse().Inverse()))
+		}
+	}
+}
@@ -5,9 +5,18 @@ Package equality provides a type class for equality with some commonly used inst
 
 - Natural uses the Equal method defined on a type
 
-- Slice can be used to create equality instances for slices
+- Reflect uses reflect.DeepEqual
+
+- Slice, Map and Pointer can be used to create equality instances for slices, maps and pointers
+
+- By and ByEq compare values by a key
+
+- And and All combine several equality instances
+
+- Epsilon and ULPs implement approximate equality for floating point numbers
 
 - StringIgnoreCase is an equality instance for strings that is not case-sensitive
 
+Equality instances for the collections in this module are provided by the respective packages, for example list.Equality or opt.Equality.
 */
 package equality
@@ -1,6 +1,9 @@
 package equality
 
-import "strings"
+import (
+	"reflect"
+	"strings"
+)
 
 // Equal is an interface for types that provide an Equal method
 type Equal[T any] interface {
@@ -57,3 +60,68 @@ func SliceNatural[T Equal[T]]() Equality[[]T] {
 func StringIgnoreCase() Equality[string] {
 	return Fun[string](strings.EqualFold)
 }
+
+// Map equality for Go maps, given equality for map values.
+// Two maps are equal if they have the same keys and equal values for each key.
+func Map[K comparable, V any](e Equality[V]) Equality[map[K]V] {
+	return Fun[map[K]V](func(a, b map[K]V) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for k, va := range a {
+			vb, ok := b[k]
+			if !ok || !e.Equal(va, vb) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Pointer equality compares the values that the pointers point to.
+// Two nil pointers are equal and a nil pointer is not equal to a non-nil pointer.
+func Pointer[T any](e Equality[T]) Equality[*T] {
+	return Fun[*T](func(a, b *T) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return a == b || e.Equal(*a, *b)
+	})
+}
+
+// By compares values by a key, using the default equality on the keys.
+func By[A any, K comparable](key func(A) K) Equality[A] {
+	return ByEq(key, Default[K]())
+}
+
+// ByEq compares values by a key, using the given equality on the keys.
+func ByEq[A, K any](key func(A) K, e Equality[K]) Equality[A] {
+	return Fun[A](func(a, b A) bool {
+		return e.Equal(key(a), key(b))
+	})
+}
+
+// And combines two equalities. Values are equal if they are equal according to both instances.
+func And[T any](a, b Equality[T]) Equality[T] {
+	return All(a, b)
+}
+
+// All combines equalities. Values are equal if they are equal according to all instances.
+// With no instances, all values are equal.
+func All[T any](es ...Equality[T]) Equality[T] {
+	return Fun[T](func(a, b T) bool {
+		for _, e := range es {
+			if !e.Equal(a, b) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Reflect equality uses reflect.DeepEqual.
+func Reflect[T any]() Equality[T] {
+	return Fun[T](func(a, b T) bool {
+		return reflect.DeepEqual(a, b)
+	})
+}
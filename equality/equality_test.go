@@ -0,0 +1,119 @@
+package equality_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+type person struct {
+	Name string
+	Age  int
+}
+
+func ExampleBy() {
+	byName := equality.By(func(p person) string { return p.Name })
+	fmt.Println(byName.Equal(person{"Alice", 30}, person{"Alice", 31}))
+	fmt.Println(byName.Equal(person{"Alice", 30}, person{"Bob", 30}))
+	// output:
+	// true
+	// false
+}
+
+func ExampleULPs() {
+	eq := equality.ULPs[float64](4)
+	x, y := 0.1, 0.2
+	fmt.Println(x+y == 0.3)
+	fmt.Println(eq.Equal(x+y, 0.3))
+	fmt.Println(eq.Equal(1e20+3e4, 1e20))
+	fmt.Println(eq.Equal(1, 1.001))
+	// output:
+	// false
+	// true
+	// true
+	// false
+}
+
+func TestMap(t *testing.T) {
+	eq := equality.Map[string](equality.StringIgnoreCase())
+	require.True(t, eq.Equal(map[string]string{"a": "x", "b": "Y"}, map[string]string{"b": "y", "a": "X"}))
+	require.True(t, eq.Equal(nil, map[string]string{}))
+	require.False(t, eq.Equal(map[string]string{"a": "x"}, map[string]string{"a": "y"}))
+	require.False(t, eq.Equal(map[string]string{"a": "x"}, map[string]string{"b": "x"}))
+	require.False(t, eq.Equal(map[string]string{"a": "x"}, map[string]string{"a": "x", "b": "x"}))
+}
+
+func TestPointer(t *testing.T) {
+	eq := equality.Pointer(equality.Default[int]())
+	x, y, z := 1, 1, 2
+	require.True(t, eq.Equal(nil, nil))
+	require.True(t, eq.Equal(&x, &y))
+	require.False(t, eq.Equal(&x, &z))
+	require.False(t, eq.Equal(&x, nil))
+	require.False(t, eq.Equal(nil, &x))
+}
+
+func TestAll(t *testing.T) {
+	byName := equality.By(func(p person) string { return p.Name })
+	byAge := equality.By(func(p person) int { return p.Age })
+	both := equality.And(byName, byAge)
+	require.True(t, both.Equal(person{"Alice", 30}, person{"Alice", 30}))
+	require.False(t, both.Equal(person{"Alice", 30}, person{"Alice", 31}))
+	require.False(t, both.Equal(person{"Alice", 30}, person{"Bob", 30}))
+	require.True(t, equality.All[person]().Equal(person{"Alice", 30}, person{"Bob", 31}))
+}
+
+func TestReflect(t *testing.T) {
+	eq := equality.Reflect[map[string][]int]()
+	require.True(t, eq.Equal(map[string][]int{"a": {1, 2}}, map[string][]int{"a": {1, 2}}))
+	require.False(t, eq.Equal(map[string][]int{"a": {1, 2}}, map[string][]int{"a": {2, 1}}))
+}
+
+func TestEpsilon(t *testing.T) {
+	eq := equality.Epsilon(0.01)
+	require.True(t, eq.Equal(1, 1.005))
+	require.True(t, eq.Equal(-1, -1.009))
+	require.False(t, eq.Equal(1, 1.02))
+	require.True(t, eq.Equal(math.Inf(1), math.Inf(1)))
+	require.False(t, eq.Equal(math.Inf(1), math.Inf(-1)))
+	require.False(t, eq.Equal(math.NaN(), math.NaN()))
+}
+
+func TestULPs(t *testing.T) {
+	eq := equality.ULPs[float64](1)
+	require.True(t, eq.Equal(0, math.Copysign(0, -1)))
+	require.True(t, eq.Equal(1, math.Nextafter(1, 2)))
+	require.False(t, eq.Equal(1, math.Nextafter(math.Nextafter(1, 2), 2)))
+	// the smallest positive and negative numbers are 2 ULPs apart, with 0 in between
+	require.False(t, eq.Equal(math.SmallestNonzeroFloat64, -math.SmallestNonzeroFloat64))
+	require.True(t, equality.ULPs[float64](2).Equal(math.SmallestNonzeroFloat64, -math.SmallestNonzeroFloat64))
+	require.False(t, eq.Equal(math.NaN(), math.NaN()))
+	require.True(t, eq.Equal(math.Inf(1), math.Inf(1)))
+	require.True(t, eq.Equal(math.MaxFloat64, math.Inf(1)))
+	require.False(t, eq.Equal(-math.MaxFloat64, math.MaxFloat64))
+
+	eq32 := equality.ULPs[float32](1)
+	require.True(t, eq32.Equal(1, math.Nextafter32(1, 2)))
+	require.False(t, eq32.Equal(1, math.Nextafter32(math.Nextafter32(1, 2), 2)))
+}
+
+func TestULPsMatchesNextafter(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		x := rapid.Float64().Draw(t, "x").(float64)
+		n := rapid.IntRange(0, 10).Draw(t, "n").(int)
+		dir := rapid.SampledFrom([]float64{math.Inf(1), math.Inf(-1)}).Draw(t, "dir").(float64)
+		y := x
+		for i := 0; i < n; i++ {
+			y = math.Nextafter(y, dir)
+		}
+		// steps across zero skip -0.0 or 0.0, which are equal
+		require.True(t, equality.ULPs[float64](uint64(n)).Equal(x, y), "%v and %v", x, y)
+		if n > 0 && y != x && !math.IsInf(y, 0) {
+			require.False(t, equality.ULPs[float64](uint64(n-1)).Equal(x, y), "%v and %v", x, y)
+		}
+	})
+}
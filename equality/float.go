@@ -0,0 +1,76 @@
+package equality
+
+import "math"
+
+// Float is a constraint for floating point types
+type Float interface {
+	float32 | float64
+}
+
+// Epsilon is an approximate equality for floating point numbers.
+// Two numbers are equal if their absolute difference is at most eps.
+// NaN is not equal to any value.
+//
+// Note that this equality is not transitive.
+func Epsilon[F Float](eps F) Equality[F] {
+	return Fun[F](func(a, b F) bool {
+		// the first check handles infinite values
+		return a == b || math.Abs(float64(a)-float64(b)) <= float64(eps)
+	})
+}
+
+// ULPs is an approximate equality for floating point numbers.
+// Two numbers are equal if there are at most maxULPs representable values of type F between them
+// (units in the last place).
+// This scales with the magnitude of the numbers, unlike Epsilon.
+// NaN is not equal to any value, and 0.0 and -0.0 are equal.
+//
+// Note that this equality is not transitive.
+func ULPs[F Float](maxULPs uint64) Equality[F] {
+	return Fun[F](func(a, b F) bool {
+		if a == b {
+			return true
+		}
+		if a != a || b != b {
+			// NaN
+			return false
+		}
+		return ulpDistance(a, b) <= maxULPs
+	})
+}
+
+// ulpDistance computes the number of representable values between a and b
+func ulpDistance[F Float](a, b F) uint64 {
+	var x, y int64
+	switch any(a).(type) {
+	case float32:
+		x = int64(orderedBits32(float32(a)))
+		y = int64(orderedBits32(float32(b)))
+	default:
+		x = orderedBits64(float64(a))
+		y = orderedBits64(float64(b))
+	}
+	if x > y {
+		return uint64(x) - uint64(y)
+	}
+	return uint64(y) - uint64(x)
+}
+
+// orderedBits64 maps a float64 to an int64, such that the order is preserved and adjacent floats are mapped to adjacent integers.
+func orderedBits64(f float64) int64 {
+	i := int64(math.Float64bits(f))
+	if i < 0 {
+		// negative numbers use sign-magnitude representation
+		return math.MinInt64 - i
+	}
+	return i
+}
+
+// orderedBits32 is like orderedBits64 for float32.
+func orderedBits32(f float32) int32 {
+	i := int32(math.Float32bits(f))
+	if i < 0 {
+		return math.MinInt32 - i
+	}
+	return i
+}
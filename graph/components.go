@@ -0,0 +1,212 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/dict/hashdict"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/list"
+	"github.com/peterzeller/go-fun/set/hashset"
+)
+
+// ErrCycle is returned when a graph contains a cycle, but an acyclic graph is required.
+var ErrCycle = fmt.Errorf("graph contains a cycle")
+
+// TopologicalSort returns the nodes of the graph in an order where each node appears before its successors.
+// If the graph contains a cycle, an error wrapping ErrCycle is returned, which includes the nodes of one cycle.
+func (g Graph[K]) TopologicalSort() (list.List[K], error) {
+	inDegree := hashdict.New[K, int](g.NodeEq())
+	var ready []K
+	for it := g.pred.Iterator(); ; {
+		e, ok := it.Next()
+		if !ok {
+			break
+		}
+		if e.Value.Size() == 0 {
+			ready = append(ready, e.Key)
+		} else {
+			inDegree = inDegree.Set(e.Key, e.Value.Size())
+		}
+	}
+	res := make([]K, 0, g.NodeCount())
+	for len(ready) > 0 {
+		n := ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+		res = append(res, n)
+		for it := g.Successors(n).Iterator(); ; {
+			s, ok := it.Next()
+			if !ok {
+				break
+			}
+			d := inDegree.GetOrZero(s) - 1
+			if d == 0 {
+				inDegree = inDegree.Remove(s)
+				ready = append(ready, s)
+			} else {
+				inDegree = inDegree.Set(s, d)
+			}
+		}
+	}
+	if len(res) < g.NodeCount() {
+		cycle, _ := g.FindCycle()
+		return list.New[K](), fmt.Errorf("%w: %v", ErrCycle, cycle)
+	}
+	return list.New(res...), nil
+}
+
+// FindCycle returns the nodes of a cycle in the graph, such that there is an edge from each node to the next one
+// and from the last node to the first one.
+// Returns false if the graph is acyclic.
+func (g Graph[K]) FindCycle() (list.List[K], bool) {
+	type frame struct {
+		node K
+		succ iterable.Iterator[K]
+	}
+	done := g.emptySet()
+	for it := g.Nodes().Iterator(); ; {
+		start, ok := it.Next()
+		if !ok {
+			return list.New[K](), false
+		}
+		if done.Contains(start) {
+			continue
+		}
+		// nodes on the current path, with their position in the path
+		onPath := hashdict.New[K, int](g.NodeEq())
+		path := []frame{{start, g.Successors(start).Iterator()}}
+		onPath = onPath.Set(start, 0)
+		for len(path) > 0 {
+			top := &path[len(path)-1]
+			s, ok := top.succ.Next()
+			if !ok {
+				done = done.Add(top.node)
+				onPath = onPath.Remove(top.node)
+				path = path[:len(path)-1]
+				continue
+			}
+			if pos, ok := onPath.Get(s); ok {
+				cycle := make([]K, 0, len(path)-pos)
+				for _, f := range path[pos:] {
+					cycle = append(cycle, f.node)
+				}
+				return list.New(cycle...), true
+			}
+			if !done.Contains(s) {
+				onPath = onPath.Set(s, len(path))
+				path = append(path, frame{s, g.Successors(s).Iterator()})
+			}
+		}
+	}
+}
+
+// StronglyConnectedComponents returns the strongly connected components of the graph.
+// Each node belongs to exactly one component.
+// The components are returned in topological order: if there is an edge from a node in component i
+// to a node in component j, then i <= j.
+func (g Graph[K]) StronglyConnectedComponents() list.List[hashset.Set[K]] {
+	// iterative version of Tarjan's algorithm
+	type frame struct {
+		node K
+		succ iterable.Iterator[K]
+	}
+	index := hashdict.New[K, int](g.NodeEq())
+	lowLink := hashdict.New[K, int](g.NodeEq())
+	onStack := g.emptySet()
+	var stack []K
+	var components []hashset.Set[K]
+	counter := 0
+
+	for it := g.Nodes().Iterator(); ; {
+		start, ok := it.Next()
+		if !ok {
+			break
+		}
+		if index.ContainsKey(start) {
+			continue
+		}
+		var callStack []frame
+		visit := func(n K) {
+			index = index.Set(n, counter)
+			lowLink = lowLink.Set(n, counter)
+			counter++
+			stack = append(stack, n)
+			onStack = onStack.Add(n)
+			callStack = append(callStack, frame{n, g.Successors(n).Iterator()})
+		}
+		visit(start)
+		for len(callStack) > 0 {
+			top := callStack[len(callStack)-1]
+			s, ok := top.succ.Next()
+			if ok {
+				if !index.ContainsKey(s) {
+					visit(s)
+				} else if onStack.Contains(s) {
+					lowLink = lowLink.Set(top.node, minInt(lowLink.GetOrZero(top.node), index.GetOrZero(s)))
+				}
+				continue
+			}
+			callStack = callStack[:len(callStack)-1]
+			low := lowLink.GetOrZero(top.node)
+			if low == index.GetOrZero(top.node) {
+				// top.node is the root of a component
+				c := g.emptySet()
+				for {
+					n := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack = onStack.Remove(n)
+					c = c.Add(n)
+					if g.NodeEq().Equal(n, top.node) {
+						break
+					}
+				}
+				components = append(components, c)
+			}
+			if len(callStack) > 0 {
+				parent := callStack[len(callStack)-1].node
+				lowLink = lowLink.Set(parent, minInt(lowLink.GetOrZero(parent), low))
+			}
+		}
+	}
+	// Tarjan's algorithm finds the components in reverse topological order
+	for i, j := 0, len(components)-1; i < j; i, j = i+1, j-1 {
+		components[i], components[j] = components[j], components[i]
+	}
+	return list.New(components...)
+}
+
+// Condensation returns the graph of strongly connected components,
+// where each component is represented by its index in the result of StronglyConnectedComponents.
+// The resulting graph is acyclic.
+func (g Graph[K]) Condensation() (Graph[int], list.List[hashset.Set[K]]) {
+	components := g.StronglyConnectedComponents()
+	componentOf := hashdict.New[K, int](g.NodeEq())
+	res := New[int](intEq)
+	for i := 0; i < components.Length(); i++ {
+		res = res.AddNode(i)
+		for it := components.At(i).Iterator(); ; {
+			n, ok := it.Next()
+			if !ok {
+				break
+			}
+			componentOf = componentOf.Set(n, i)
+		}
+	}
+	for it := g.Edges().Iterator(); ; {
+		e, ok := it.Next()
+		if !ok {
+			break
+		}
+		from, to := componentOf.GetOrZero(e.From), componentOf.GetOrZero(e.To)
+		if from != to {
+			res = res.AddEdge(from, to)
+		}
+	}
+	return res, components
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,11 @@
+/*
+Package graph implements an immutable directed graph and common graph algorithms.
+
+The graph stores successors and predecessors of each node in hash sets,
+so both directions can be queried efficiently and updates share structure with previous versions.
+
+Traversals (DFS, BFS) are lazy and return iterables.
+Other algorithms include topological sorting with cycle reporting, strongly connected components,
+shortest paths and transitive closure.
+*/
+package graph
@@ -0,0 +1,249 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/dict/hashdict"
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/set/hashset"
+)
+
+// Graph is an immutable directed graph with nodes of type K.
+// There is at most one edge between two nodes in each direction and edges from a node to itself are allowed.
+type Graph[K any] struct {
+	// every node is a key in both succ and pred, possibly with an empty set
+	succ  hashdict.Dict[K, hashset.Set[K]]
+	pred  hashdict.Dict[K, hashset.Set[K]]
+	edges int
+}
+
+var _ equality.Equal[Graph[int]] = Graph[int]{}
+
+// Edge is a directed edge in a graph
+type Edge[K any] struct {
+	From K
+	To   K
+}
+
+func (e Edge[K]) String() string {
+	return fmt.Sprintf("%v -> %v", e.From, e.To)
+}
+
+// New creates a new graph with the given nodes and no edges.
+func New[K any](eq hash.EqHash[K], nodes ...K) Graph[K] {
+	g := Graph[K]{
+		succ: hashdict.New[K, hashset.Set[K]](eq),
+		pred: hashdict.New[K, hashset.Set[K]](eq),
+	}
+	for _, n := range nodes {
+		g = g.AddNode(n)
+	}
+	return g
+}
+
+// FromEdges creates a new graph with the given edges and the nodes occurring in the edges.
+func FromEdges[K any](eq hash.EqHash[K], edges ...Edge[K]) Graph[K] {
+	g := New[K](eq)
+	for _, e := range edges {
+		g = g.AddEdge(e.From, e.To)
+	}
+	return g
+}
+
+// FromDict creates a new graph from a dictionary mapping each node to its successors.
+func FromDict[K any](d hashdict.Dict[K, hashset.Set[K]]) Graph[K] {
+	g := New[K](d.KeyEq())
+	for it := d.Iterator(); ; {
+		e, ok := it.Next()
+		if !ok {
+			return g
+		}
+		g = g.AddNode(e.Key)
+		for it2 := e.Value.Iterator(); ; {
+			to, ok := it2.Next()
+			if !ok {
+				break
+			}
+			g = g.AddEdge(e.Key, to)
+		}
+	}
+}
+
+// NodeEq returns the EqHash used for nodes
+func (g Graph[K]) NodeEq() hash.EqHash[K] {
+	return g.succ.KeyEq()
+}
+
+func (g Graph[K]) emptySet() hashset.Set[K] {
+	return hashset.New(g.NodeEq())
+}
+
+// AddNode returns a graph that contains the given node.
+func (g Graph[K]) AddNode(n K) Graph[K] {
+	if g.succ.ContainsKey(n) {
+		return g
+	}
+	return Graph[K]{
+		succ:  g.succ.Set(n, g.emptySet()),
+		pred:  g.pred.Set(n, g.emptySet()),
+		edges: g.edges,
+	}
+}
+
+// RemoveNode returns a graph without the given node and without the edges to and from the node.
+func (g Graph[K]) RemoveNode(n K) Graph[K] {
+	out, ok := g.succ.Get(n)
+	if !ok {
+		return g
+	}
+	in, _ := g.pred.Get(n)
+	succ := g.succ.Remove(n)
+	pred := g.pred.Remove(n)
+	edges := g.edges - out.Size() - in.Size()
+	if out.Contains(n) {
+		// self loop was counted twice
+		edges++
+	}
+	for it := out.Iterator(); ; {
+		to, ok := it.Next()
+		if !ok {
+			break
+		}
+		pred = removeFrom(pred, to, n)
+	}
+	for it := in.Iterator(); ; {
+		from, ok := it.Next()
+		if !ok {
+			break
+		}
+		succ = removeFrom(succ, from, n)
+	}
+	return Graph[K]{succ: succ, pred: pred, edges: edges}
+}
+
+func removeFrom[K any](d hashdict.Dict[K, hashset.Set[K]], key K, elem K) hashdict.Dict[K, hashset.Set[K]] {
+	if s, ok := d.Get(key); ok {
+		return d.Set(key, s.Remove(elem))
+	}
+	return d
+}
+
+// AddEdge returns a graph with an edge from one node to another.
+// The nodes are added to the graph if necessary.
+func (g Graph[K]) AddEdge(from, to K) Graph[K] {
+	if g.ContainsEdge(from, to) {
+		return g
+	}
+	g = g.AddNode(from).AddNode(to)
+	return Graph[K]{
+		succ:  g.succ.Set(from, g.succ.GetOrZero(from).Add(to)),
+		pred:  g.pred.Set(to, g.pred.GetOrZero(to).Add(from)),
+		edges: g.edges + 1,
+	}
+}
+
+// RemoveEdge returns a graph without the edge from one node to another.
+// The nodes remain in the graph.
+func (g Graph[K]) RemoveEdge(from, to K) Graph[K] {
+	if !g.ContainsEdge(from, to) {
+		return g
+	}
+	return Graph[K]{
+		succ:  removeFrom(g.succ, from, to),
+		pred:  removeFrom(g.pred, to, from),
+		edges: g.edges - 1,
+	}
+}
+
+// ContainsNode checks whether the given node is in the graph.
+func (g Graph[K]) ContainsNode(n K) bool {
+	return g.succ.ContainsKey(n)
+}
+
+// ContainsEdge checks whether there is an edge from one node to another.
+func (g Graph[K]) ContainsEdge(from, to K) bool {
+	s, ok := g.succ.Get(from)
+	return ok && s.Contains(to)
+}
+
+// Successors returns the nodes with an edge from the given node.
+// Returns an empty set if the node is not in the graph.
+func (g Graph[K]) Successors(n K) hashset.Set[K] {
+	if s, ok := g.succ.Get(n); ok {
+		return s
+	}
+	return g.emptySet()
+}
+
+// Predecessors returns the nodes with an edge to the given node.
+// Returns an empty set if the node is not in the graph.
+func (g Graph[K]) Predecessors(n K) hashset.Set[K] {
+	if s, ok := g.pred.Get(n); ok {
+		return s
+	}
+	return g.emptySet()
+}
+
+// Nodes returns all nodes of the graph.
+func (g Graph[K]) Nodes() iterable.Iterable[K] {
+	return g.succ.Keys()
+}
+
+// NodeSet returns all nodes of the graph as a set.
+func (g Graph[K]) NodeSet() hashset.Set[K] {
+	return hashset.FromIterable(g.NodeEq(), g.Nodes())
+}
+
+// Edges returns all edges of the graph.
+func (g Graph[K]) Edges() iterable.Iterable[Edge[K]] {
+	return iterable.FlatMap[dict.Entry[K, hashset.Set[K]], Edge[K]](g.succ, func(e dict.Entry[K, hashset.Set[K]]) iterable.Iterable[Edge[K]] {
+		return iterable.Map[K, Edge[K]](e.Value, func(to K) Edge[K] {
+			return Edge[K]{From: e.Key, To: to}
+		})
+	})
+}
+
+// NodeCount returns the number of nodes in the graph.
+func (g Graph[K]) NodeCount() int {
+	return g.succ.Size()
+}
+
+// EdgeCount returns the number of edges in the graph.
+func (g Graph[K]) EdgeCount() int {
+	return g.edges
+}
+
+// Reverse returns the graph with all edges reversed.
+// This is a constant time operation.
+func (g Graph[K]) Reverse() Graph[K] {
+	return Graph[K]{succ: g.pred, pred: g.succ, edges: g.edges}
+}
+
+// AsDict returns a dictionary mapping each node to its successors.
+func (g Graph[K]) AsDict() hashdict.Dict[K, hashset.Set[K]] {
+	return g.succ
+}
+
+// Equal checks whether both graphs have the same nodes and edges.
+func (g Graph[K]) Equal(other Graph[K]) bool {
+	if g.NodeCount() != other.NodeCount() || g.EdgeCount() != other.EdgeCount() {
+		return false
+	}
+	for it := g.succ.Iterator(); ; {
+		e, ok := it.Next()
+		if !ok {
+			return true
+		}
+		s, ok := other.succ.Get(e.Key)
+		if !ok || !s.Equal(e.Value) {
+			return false
+		}
+	}
+}
+
+func (g Graph[K]) String() string {
+	return iterable.String[dict.Entry[K, hashset.Set[K]]](g.succ)
+}
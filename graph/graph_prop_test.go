@@ -0,0 +1,241 @@
+package graph_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/peterzeller/go-fun/graph"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+const nodeCount = 8
+
+// model of a graph as an adjacency matrix
+type model [nodeCount][nodeCount]bool
+
+func genGraph(t *rapid.T) (graph.Graph[int], model) {
+	var m model
+	g := graph.New(hash.Num[int](), 0, 1, 2, 3, 4, 5, 6, 7)
+	n := rapid.IntRange(0, 20).Draw(t, "edgeCount").(int)
+	for i := 0; i < n; i++ {
+		from := rapid.IntRange(0, nodeCount-1).Draw(t, "from").(int)
+		to := rapid.IntRange(0, nodeCount-1).Draw(t, "to").(int)
+		m[from][to] = true
+		g = g.AddEdge(from, to)
+	}
+	return g, m
+}
+
+// distances computes the number of edges on shortest paths (Floyd-Warshall), -1 if unreachable
+func (m model) distances(weight func(a, b int) int) [nodeCount][nodeCount]int {
+	var d [nodeCount][nodeCount]int
+	for i := range d {
+		for j := range d[i] {
+			d[i][j] = -1
+			if m[i][j] {
+				d[i][j] = weight(i, j)
+			}
+		}
+	}
+	for k := 0; k < nodeCount; k++ {
+		for i := 0; i < nodeCount; i++ {
+			for j := 0; j < nodeCount; j++ {
+				if d[i][k] >= 0 && d[k][j] >= 0 && (d[i][j] < 0 || d[i][k]+d[k][j] < d[i][j]) {
+					d[i][j] = d[i][k] + d[k][j]
+				}
+			}
+		}
+	}
+	return d
+}
+
+func unitWeight(a, b int) int {
+	return 1
+}
+
+func TestGraphStructure(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		g, m := genGraph(t)
+		removed := rapid.IntRange(0, nodeCount-1).Draw(t, "removed").(int)
+		if rapid.Bool().Draw(t, "removeNode").(bool) {
+			g = g.RemoveNode(removed)
+			for i := 0; i < nodeCount; i++ {
+				m[i][removed] = false
+				m[removed][i] = false
+			}
+			require.False(t, g.ContainsNode(removed))
+		} else {
+			other := rapid.IntRange(0, nodeCount-1).Draw(t, "other").(int)
+			g = g.RemoveEdge(removed, other)
+			m[removed][other] = false
+		}
+		edges := 0
+		for i := 0; i < nodeCount; i++ {
+			for j := 0; j < nodeCount; j++ {
+				require.Equal(t, m[i][j], g.ContainsEdge(i, j))
+				require.Equal(t, m[i][j], g.Successors(i).Contains(j))
+				require.Equal(t, m[i][j], g.Predecessors(j).Contains(i))
+				if m[i][j] {
+					edges++
+				}
+			}
+		}
+		require.Equal(t, edges, g.EdgeCount())
+		require.Equal(t, edges, iterable.Length(g.Edges()))
+		require.True(t, g.Equal(g.Reverse().Reverse()))
+		require.Equal(t, edges, g.Reverse().EdgeCount())
+	})
+}
+
+func TestTraversals(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		g, m := genGraph(t)
+		d := m.distances(unitWeight)
+		start := rapid.IntRange(0, nodeCount-1).Draw(t, "start").(int)
+		reachable := g.Reachable(start)
+		bfs := iterable.ToSlice(g.BFS(start))
+		dfs := iterable.ToSlice(g.DFS(start))
+		require.Equal(t, reachable.Size(), len(bfs))
+		require.Equal(t, reachable.Size(), len(dfs))
+		require.Equal(t, start, bfs[0])
+		require.Equal(t, start, dfs[0])
+		for j := 0; j < nodeCount; j++ {
+			require.Equal(t, j == start || d[start][j] >= 0, reachable.Contains(j))
+		}
+		// BFS visits nodes in order of their distance
+		for i := 1; i < len(bfs); i++ {
+			require.LessOrEqual(t, dist(d, start, bfs[i-1]), dist(d, start, bfs[i]))
+		}
+		// in DFS pre-order, each node except the start is a successor of an earlier node
+		for i := 1; i < len(dfs); i++ {
+			found := false
+			for _, p := range dfs[:i] {
+				found = found || m[p][dfs[i]]
+			}
+			require.True(t, found)
+		}
+	})
+}
+
+func dist(d [nodeCount][nodeCount]int, from, to int) int {
+	if from == to {
+		return 0
+	}
+	return d[from][to]
+}
+
+func TestTopologicalSort(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		g, m := genGraph(t)
+		d := m.distances(unitWeight)
+		cyclic := false
+		for i := 0; i < nodeCount; i++ {
+			cyclic = cyclic || d[i][i] >= 0
+		}
+		order, err := g.TopologicalSort()
+		cycle, hasCycle := g.FindCycle()
+		require.Equal(t, cyclic, hasCycle)
+		if cyclic {
+			require.True(t, errors.Is(err, graph.ErrCycle))
+			n := cycle.Length()
+			require.Greater(t, n, 0)
+			for i := 0; i < n; i++ {
+				require.True(t, m[cycle.At(i)][cycle.At((i+1)%n)], "cycle %v", cycle)
+			}
+			return
+		}
+		require.NoError(t, err)
+		require.Equal(t, nodeCount, order.Length())
+		pos := make(map[int]int)
+		for i := 0; i < order.Length(); i++ {
+			pos[order.At(i)] = i
+		}
+		for i := 0; i < nodeCount; i++ {
+			for j := 0; j < nodeCount; j++ {
+				if m[i][j] {
+					require.Less(t, pos[i], pos[j])
+				}
+			}
+		}
+	})
+}
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		g, m := genGraph(t)
+		d := m.distances(unitWeight)
+		components := g.StronglyConnectedComponents()
+		componentOf := make(map[int]int)
+		for i := 0; i < components.Length(); i++ {
+			for _, n := range iterable.ToSlice[int](components.At(i)) {
+				_, dup := componentOf[n]
+				require.False(t, dup)
+				componentOf[n] = i
+			}
+		}
+		require.Equal(t, nodeCount, len(componentOf))
+		for i := 0; i < nodeCount; i++ {
+			for j := 0; j < nodeCount; j++ {
+				mutual := i == j || d[i][j] >= 0 && d[j][i] >= 0
+				require.Equal(t, mutual, componentOf[i] == componentOf[j], "nodes %d and %d", i, j)
+				if m[i][j] {
+					require.LessOrEqual(t, componentOf[i], componentOf[j])
+				}
+			}
+		}
+		condensed, _ := g.Condensation()
+		_, err := condensed.TopologicalSort()
+		require.NoError(t, err)
+	})
+}
+
+func TestTransitiveClosure(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		g, m := genGraph(t)
+		d := m.distances(unitWeight)
+		closure := g.TransitiveClosure()
+		require.Equal(t, nodeCount, closure.NodeCount())
+		for i := 0; i < nodeCount; i++ {
+			for j := 0; j < nodeCount; j++ {
+				require.Equal(t, d[i][j] >= 0, closure.ContainsEdge(i, j), "edge %d -> %d", i, j)
+			}
+		}
+	})
+}
+
+func TestShortestPaths(t *testing.T) {
+	weight := func(a, b int) int { return (a*b)%5 + 1 }
+	rapid.Check(t, func(t *rapid.T) {
+		g, m := genGraph(t)
+		d := m.distances(unitWeight)
+		wd := m.distances(weight)
+		from := rapid.IntRange(0, nodeCount-1).Draw(t, "from").(int)
+		paths := graph.ShortestPaths(g, from, weight)
+		for to := 0; to < nodeCount; to++ {
+			path, ok := g.ShortestPath(from, to)
+			require.Equal(t, dist(d, from, to) >= 0, ok)
+			if ok {
+				require.Equal(t, dist(d, from, to), path.Length()-1)
+				require.Equal(t, from, path.At(0))
+				require.Equal(t, to, path.At(path.Length()-1))
+			}
+			w, ok := paths.Distance(to)
+			require.Equal(t, dist(wd, from, to) >= 0, ok)
+			if !ok {
+				continue
+			}
+			require.Equal(t, dist(wd, from, to), w)
+			wpath, ok := paths.PathTo(to)
+			require.True(t, ok)
+			sum := 0
+			for i := 1; i < wpath.Length(); i++ {
+				require.True(t, m[wpath.At(i-1)][wpath.At(i)])
+				sum += weight(wpath.At(i-1), wpath.At(i))
+			}
+			require.Equal(t, w, sum)
+		}
+	})
+}
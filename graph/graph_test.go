@@ -0,0 +1,64 @@
+package graph_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/peterzeller/go-fun/graph"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+)
+
+func ExampleGraph_TopologicalSort() {
+	deps := graph.FromEdges(hash.String(),
+		graph.Edge[string]{From: "app", To: "lib"},
+		graph.Edge[string]{From: "lib", To: "base"},
+		graph.Edge[string]{From: "app", To: "base"})
+	order, err := deps.TopologicalSort()
+	fmt.Printf("%v %v\n", order, err)
+
+	cyclic := deps.AddEdge("base", "app")
+	_, err = cyclic.TopologicalSort()
+	fmt.Printf("%v\n", errors.Is(err, graph.ErrCycle))
+	// output:
+	// [app, lib, base] <nil>
+	// true
+}
+
+func ExampleGraph_BFS() {
+	g := graph.FromEdges(hash.Num[int](),
+		graph.Edge[int]{From: 1, To: 2},
+		graph.Edge[int]{From: 2, To: 3},
+		graph.Edge[int]{From: 3, To: 4},
+		graph.Edge[int]{From: 1, To: 5})
+	fmt.Printf("%v\n", iterable.ToSlice(iterable.Take(3, g.BFS(1))))
+	path, _ := g.ShortestPath(1, 4)
+	fmt.Printf("%v\n", path)
+	// output:
+	// [1 2 5]
+	// [1, 2, 3, 4]
+}
+
+func ExampleShortestPaths() {
+	g := graph.FromEdges(hash.String(),
+		graph.Edge[string]{From: "a", To: "b"},
+		graph.Edge[string]{From: "b", To: "c"},
+		graph.Edge[string]{From: "a", To: "c"})
+	weights := map[string]int{"ab": 1, "bc": 2, "ac": 5}
+	paths := graph.ShortestPaths(g, "a", func(from, to string) int { return weights[from+to] })
+	d, _ := paths.Distance("c")
+	p, _ := paths.PathTo("c")
+	fmt.Printf("distance %v via %v\n", d, p)
+	// output:
+	// distance 3 via [a, b, c]
+}
+
+func ExampleGraph_StronglyConnectedComponents() {
+	g := graph.FromEdges(hash.Num[int](),
+		graph.Edge[int]{From: 1, To: 2},
+		graph.Edge[int]{From: 2, To: 1},
+		graph.Edge[int]{From: 2, To: 3})
+	fmt.Printf("%v\n", g.StronglyConnectedComponents())
+	// output:
+	// [[1, 2], [3]]
+}
@@ -0,0 +1,138 @@
+package graph
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/dict/hashdict"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/list"
+	"github.com/peterzeller/go-fun/mutable"
+)
+
+var intEq = hash.Num[int]()
+
+// ShortestPath returns a path with the minimal number of edges from one node to another.
+// The path includes both the start and the end node.
+// Returns false if there is no such path.
+func (g Graph[K]) ShortestPath(from, to K) (list.List[K], bool) {
+	if !g.ContainsNode(from) || !g.ContainsNode(to) {
+		return list.New[K](), false
+	}
+	prev := hashdict.New[K, K](g.NodeEq())
+	visited := g.emptySet().Add(from)
+	queue := mutable.NewQueue(from)
+	for !queue.Empty() {
+		n := queue.Pop()
+		if g.NodeEq().Equal(n, to) {
+			return buildPath(prev, from, to), true
+		}
+		for it := g.Successors(n).Iterator(); ; {
+			s, ok := it.Next()
+			if !ok {
+				break
+			}
+			if !visited.Contains(s) {
+				visited = visited.Add(s)
+				prev = prev.Set(s, n)
+				queue.Push(s)
+			}
+		}
+	}
+	return list.New[K](), false
+}
+
+// buildPath follows the prev links back from the target to the source
+func buildPath[K any](prev hashdict.Dict[K, K], from, to K) list.List[K] {
+	eq := prev.KeyEq()
+	path := []K{to}
+	for n := to; !eq.Equal(n, from); {
+		n = prev.GetOrZero(n)
+		path = append(path, n)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return list.New(path...)
+}
+
+// Paths contains the shortest paths from a source node to all reachable nodes.
+type Paths[K any, W iterable.Number] struct {
+	source K
+	dist   hashdict.Dict[K, W]
+	prev   hashdict.Dict[K, K]
+}
+
+// Source returns the node from which the paths start.
+func (p Paths[K, W]) Source() K {
+	return p.source
+}
+
+// Distance returns the length of the shortest path to the given node.
+// Returns false if the node is not reachable from the source.
+func (p Paths[K, W]) Distance(to K) (W, bool) {
+	return p.dist.Get(to)
+}
+
+// PathTo returns the shortest path from the source to the given node, including both nodes.
+// Returns false if the node is not reachable from the source.
+func (p Paths[K, W]) PathTo(to K) (list.List[K], bool) {
+	if !p.dist.ContainsKey(to) {
+		return list.New[K](), false
+	}
+	return buildPath(p.prev, p.source, to), true
+}
+
+// Distances returns the lengths of the shortest paths to all reachable nodes.
+func (p Paths[K, W]) Distances() hashdict.Dict[K, W] {
+	return p.dist
+}
+
+// ShortestPaths computes the shortest paths from the source node to all reachable nodes using Dijkstra's algorithm.
+// The weight function returns the length of an edge.
+// Panics if an edge has a negative weight.
+func ShortestPaths[K any, W iterable.Number](g Graph[K], source K, weight func(from, to K) W) Paths[K, W] {
+	type item struct {
+		node K
+		dist W
+	}
+	res := Paths[K, W]{
+		source: source,
+		dist:   hashdict.New[K, W](g.NodeEq()),
+		prev:   hashdict.New[K, K](g.NodeEq()),
+	}
+	if !g.ContainsNode(source) {
+		return res
+	}
+	tentative := hashdict.New[K, W](g.NodeEq(), dict.E(source, W(0)))
+	heap := mutable.NewHeap(func(a, b item) bool { return a.dist < b.dist }, item{source, 0})
+	for !heap.Empty() {
+		current := heap.Pop()
+		if res.dist.ContainsKey(current.node) {
+			// outdated entry for a node that is already done
+			continue
+		}
+		res.dist = res.dist.Set(current.node, current.dist)
+		for it := g.Successors(current.node).Iterator(); ; {
+			s, ok := it.Next()
+			if !ok {
+				break
+			}
+			w := weight(current.node, s)
+			if w < 0 {
+				panic(fmt.Errorf("negative weight %v for edge %v -> %v", w, current.node, s))
+			}
+			if res.dist.ContainsKey(s) {
+				continue
+			}
+			d := current.dist + w
+			if old, ok := tentative.Get(s); !ok || d < old {
+				tentative = tentative.Set(s, d)
+				res.prev = res.prev.Set(s, current.node)
+				heap.Push(item{s, d})
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,143 @@
+package graph
+
+import (
+	"github.com/peterzeller/go-fun/dict/hashdict"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/mutable"
+	"github.com/peterzeller/go-fun/set/hashset"
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// DFS returns the nodes reachable from the given start nodes in depth-first pre-order.
+// Each node is returned at most once and start nodes that are not in the graph are ignored.
+// The traversal is lazy: nodes are only visited while the iterator is consumed.
+//
+// DFS uses an explicit stack instead of recursively applying iterable.FlatMap to the successors:
+// nested FlatMap iterators would make each step cost O(depth) and nest as deep as the longest path.
+func (g Graph[K]) DFS(starts ...K) iterable.Iterable[K] {
+	return iterable.IterableFun[K](func() iterable.Iterator[K] {
+		visited := g.emptySet()
+		stack := mutable.NewStack[K]()
+		for i := len(starts) - 1; i >= 0; i-- {
+			stack.Push(starts[i])
+		}
+		return iterable.Fun[K](func() (K, bool) {
+			for !stack.Empty() {
+				n := stack.Pop()
+				if visited.Contains(n) || !g.ContainsNode(n) {
+					continue
+				}
+				visited = visited.Add(n)
+				// push in reverse order, so that successors are visited in iteration order
+				succ := iterable.ToSlice[K](g.Successors(n))
+				for i := len(succ) - 1; i >= 0; i-- {
+					if !visited.Contains(succ[i]) {
+						stack.Push(succ[i])
+					}
+				}
+				return n, true
+			}
+			return zero.Value[K](), false
+		})
+	})
+}
+
+// BFS returns the nodes reachable from the given start nodes in breadth-first order.
+// Each node is returned at most once and start nodes that are not in the graph are ignored.
+// The traversal is lazy: nodes are only visited while the iterator is consumed.
+//
+// BFS uses an explicit queue instead of iterable.FlatMapBreadthFirst,
+// because the latter interleaves the inner iterables round-robin, which does not visit nodes level by level.
+func (g Graph[K]) BFS(starts ...K) iterable.Iterable[K] {
+	return iterable.IterableFun[K](func() iterable.Iterator[K] {
+		visited := g.emptySet()
+		queue := mutable.NewQueue[K]()
+		for _, s := range starts {
+			if g.ContainsNode(s) && !visited.Contains(s) {
+				visited = visited.Add(s)
+				queue.Push(s)
+			}
+		}
+		return iterable.Fun[K](func() (K, bool) {
+			if queue.Empty() {
+				return zero.Value[K](), false
+			}
+			n := queue.Pop()
+			for it := g.Successors(n).Iterator(); ; {
+				s, ok := it.Next()
+				if !ok {
+					break
+				}
+				if !visited.Contains(s) {
+					visited = visited.Add(s)
+					queue.Push(s)
+				}
+			}
+			return n, true
+		})
+	})
+}
+
+// Reachable returns the set of nodes reachable from the given start nodes, including the start nodes.
+func (g Graph[K]) Reachable(starts ...K) hashset.Set[K] {
+	return hashset.FromIterable(g.NodeEq(), g.DFS(starts...))
+}
+
+// TransitiveClosure returns a graph with the same nodes and an edge from a to b
+// whenever b is reachable from a by a non-empty path in this graph.
+func (g Graph[K]) TransitiveClosure() Graph[K] {
+	res := g
+	// nodes in the same strongly connected component have the same reachable nodes,
+	// so process components in reverse topological order and reuse the results of successors.
+	reach := make([]hashset.Set[K], 0)
+	componentOf := hashdict.New[K, int](g.NodeEq())
+	components := g.StronglyConnectedComponents()
+	for i := components.Length() - 1; i >= 0; i-- {
+		c := components.At(i)
+		r := g.emptySet()
+		for it := c.Iterator(); ; {
+			n, ok := it.Next()
+			if !ok {
+				break
+			}
+			componentOf = componentOf.Set(n, len(reach))
+		}
+		cyclic := c.Size() > 1
+		for it := c.Iterator(); ; {
+			n, ok := it.Next()
+			if !ok {
+				break
+			}
+			for it2 := g.Successors(n).Iterator(); ; {
+				s, ok := it2.Next()
+				if !ok {
+					break
+				}
+				sc := componentOf.GetOrZero(s)
+				if sc == len(reach) {
+					cyclic = true
+					continue
+				}
+				r = r.Union(reach[sc]).Add(s)
+			}
+		}
+		if cyclic {
+			r = r.Union(c)
+		}
+		reach = append(reach, r)
+		for it := c.Iterator(); ; {
+			n, ok := it.Next()
+			if !ok {
+				break
+			}
+			for it2 := r.Iterator(); ; {
+				s, ok := it2.Next()
+				if !ok {
+					break
+				}
+				res = res.AddEdge(n, s)
+			}
+		}
+	}
+	return res
+}
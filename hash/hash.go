@@ -50,11 +50,7 @@ var stringEq EqHash[string] = Fun[string]{
 	Eq: func(a, b string) bool {
 		return a == b
 	},
-	H: func(a string) int64 {
-		h := fnv.New64a()
-		h.Write([]byte(a))
-		return int64(h.Sum64())
-	},
+	H: hashString,
 }
 
 func String() EqHash[string] {
@@ -120,12 +116,17 @@ type Pair[A, B any] struct {
 	B B
 }
 
+// PairEq creates an equality instance for a pair, combining two equality instances
+func PairEq[A, B any](a equality.Equality[A], b equality.Equality[B]) equality.Equality[Pair[A, B]] {
+	return equality.Fun[Pair[A, B]](func(x, y Pair[A, B]) bool {
+		return a.Equal(x.A, y.A) && b.Equal(x.B, y.B)
+	})
+}
+
 // PairHash creates an EqHash instance for a pair, combining two EqHash instances
 func PairHash[A, B any](a EqHash[A], b EqHash[B]) EqHash[Pair[A, B]] {
 	return Fun[Pair[A, B]]{
-		Eq: func(x, y Pair[A, B]) bool {
-			return a.Equal(x.A, y.A) && b.Equal(x.B, y.B)
-		},
+		Eq: PairEq[A, B](a, b).Equal,
 		H: func(v Pair[A, B]) int64 {
 			return CombineHashes(a.Hash(v.A), b.Hash(v.B))
 		},
@@ -0,0 +1,41 @@
+package hash
+
+import "fmt"
+
+// ErrLawViolated is returned by CheckLaws when an EqHash instance is not consistent
+var ErrLawViolated = fmt.Errorf("EqHash law violated")
+
+// CheckLaws checks that an EqHash instance is consistent on the given sample values:
+//
+// - equality is reflexive and symmetric
+//
+// - equal values have equal hashes
+//
+// - the hash of a value is deterministic
+//
+// Returns an error wrapping ErrLawViolated for the first violation found.
+// This is intended to be used in tests, for example with randomly generated values.
+func CheckLaws[T any](e EqHash[T], values ...T) error {
+	hashes := make([]int64, len(values))
+	for i, a := range values {
+		hashes[i] = e.Hash(a)
+		if h := e.Hash(a); h != hashes[i] {
+			return fmt.Errorf("%w: hash of %v is not deterministic (%d != %d)", ErrLawViolated, a, hashes[i], h)
+		}
+		if !e.Equal(a, a) {
+			return fmt.Errorf("%w: %v is not equal to itself", ErrLawViolated, a)
+		}
+	}
+	for i, a := range values {
+		for j, b := range values[:i] {
+			eq := e.Equal(a, b)
+			if eq != e.Equal(b, a) {
+				return fmt.Errorf("%w: equality of %v and %v is not symmetric", ErrLawViolated, a, b)
+			}
+			if eq && hashes[i] != hashes[j] {
+				return fmt.Errorf("%w: %v and %v are equal but have different hashes (%d != %d)", ErrLawViolated, a, b, hashes[i], hashes[j])
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package hash
+
+import (
+	"bytes"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// StringBy creates an EqHash instance for strings that are equal after normalizing them with the given function.
+// The normalize function must be deterministic.
+func StringBy(normalize func(string) string) EqHash[string] {
+	return Fun[string]{
+		Eq: func(a, b string) bool {
+			return a == b || normalize(a) == normalize(b)
+		},
+		H: func(a string) int64 {
+			return hashString(normalize(a))
+		},
+	}
+}
+
+func hashString(s string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return int64(h.Sum64())
+}
+
+// StringIgnoreCase is an EqHash instance for strings that is not case-sensitive.
+// Equality is the same as strings.EqualFold and equality.StringIgnoreCase, which use simple Unicode case folding.
+// Use StringCaseFold for full case folding.
+func StringIgnoreCase() EqHash[string] {
+	return Fun[string]{
+		Eq: strings.EqualFold,
+		H: func(a string) int64 {
+			return hashString(strings.Map(foldRune, a))
+		},
+	}
+}
+
+// foldRune maps a rune to the smallest rune that is equivalent under simple case folding
+func foldRune(r rune) rune {
+	res := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < res {
+			res = f
+		}
+	}
+	return res
+}
+
+// StringCaseFold is an EqHash instance for strings that are equal under canonical caseless matching,
+// as defined by the Unicode standard.
+// It uses full case folding, so for example "Straße" and "STRASSE" are equal.
+func StringCaseFold() EqHash[string] {
+	return StringBy(func(s string) string {
+		// a Caser must not be shared between goroutines, so we create a new one for each call
+		return norm.NFD.String(cases.Fold().String(norm.NFD.String(s)))
+	})
+}
+
+// StringNFC is an EqHash instance for strings that are canonically equivalent in Unicode,
+// i.e. that are equal after normalizing them to NFC.
+// For example, "é" written as a single code point is equal to "e" followed by a combining accent.
+func StringNFC() EqHash[string] {
+	return StringBy(norm.NFC.String)
+}
+
+// StringNFKC is an EqHash instance for strings that are compatibility equivalent in Unicode,
+// i.e. that are equal after normalizing them to NFKC.
+// This is a weaker equality than StringNFC. For example, the ligature "ﬁ" is equal to "fi".
+func StringNFKC() EqHash[string] {
+	return StringBy(norm.NFKC.String)
+}
+
+// StringCollapseSpace is an EqHash instance for strings that ignores leading and trailing white space
+// and treats each sequence of white space characters like a single space.
+func StringCollapseSpace() EqHash[string] {
+	return StringBy(func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	})
+}
+
+// StringCollation is an EqHash instance for strings that compare as equal in the locale-independent root collation
+// of the Unicode Collation Algorithm.
+// The options can be used to make the collation weaker, for example collate.IgnoreCase or collate.Loose.
+func StringCollation(opts ...collate.Option) EqHash[string] {
+	c := &collator{c: collate.New(language.Und, opts...)}
+	return Fun[string]{
+		Eq: func(a, b string) bool {
+			return a == b || c.equal(a, b)
+		},
+		H: c.hash,
+	}
+}
+
+// collator makes a collate.Collator safe for concurrent use
+type collator struct {
+	mutex sync.Mutex
+	c     *collate.Collator
+	buf   collate.Buffer
+}
+
+func (c *collator) equal(a, b string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	defer c.buf.Reset()
+	return bytes.Equal(c.c.KeyFromString(&c.buf, a), c.c.KeyFromString(&c.buf, b))
+}
+
+func (c *collator) hash(a string) int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	defer c.buf.Reset()
+	h := fnv.New64a()
+	h.Write(c.c.KeyFromString(&c.buf, a))
+	return int64(h.Sum64())
+}
@@ -0,0 +1,113 @@
+package hash_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/peterzeller/go-fun/dict/hashdict"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/collate"
+	"pgregory.net/rapid"
+)
+
+func ExampleStringIgnoreCase() {
+	d := hashdict.New[string, int](hash.StringIgnoreCase())
+	d = d.Set("Hello", 1)
+	d = d.Set("HELLO", 2)
+	fmt.Println(d.Size(), d.GetOrZero("hello"))
+	// output: 1 2
+}
+
+func ExampleStringNFC() {
+	composed := "café"
+	decomposed := "café"
+	fmt.Println(composed == decomposed, hash.StringNFC().Equal(composed, decomposed))
+	// output: false true
+}
+
+func ExampleStringCollapseSpace() {
+	fmt.Println(hash.StringCollapseSpace().Equal("  hello \t\n world ", "hello world"))
+	// output: true
+}
+
+// tokens that are interesting for the different string equalities
+var stringTokens = []string{
+	"a", "A", "s", "S", "ss", "SS", "ß", "ẞ", "ſ", "k", "K", "K",
+	"σ", "Σ", "ς", "i", "I", "İ", "ı", "é", "é", "É", "É",
+	"ﬁ", "fi", "①", "1", "x", " ", "  ", "\t", "\n", " ", "\xff", "�",
+}
+
+// genRelatedStrings generates a list of strings that are built from the same small set of tokens,
+// so that many of them are equal under the different string equalities.
+func genRelatedStrings() *rapid.Generator {
+	token := rapid.SampledFrom(stringTokens)
+	str := rapid.Custom(func(t *rapid.T) string {
+		return strings.Join(rapid.SliceOfN(token, 0, 4).Draw(t, "tokens").([]string), "")
+	})
+	return rapid.SliceOfN(str, 1, 20)
+}
+
+var stringEqHashes = map[string]hash.EqHash[string]{
+	"String":                    hash.String(),
+	"StringIgnoreCase":          hash.StringIgnoreCase(),
+	"StringCaseFold":            hash.StringCaseFold(),
+	"StringNFC":                 hash.StringNFC(),
+	"StringNFKC":                hash.StringNFKC(),
+	"StringCollapseSpace":       hash.StringCollapseSpace(),
+	"StringCollation":           hash.StringCollation(),
+	"StringCollation(Loose)":    hash.StringCollation(collate.Loose),
+	"StringCollation(Numeric)":  hash.StringCollation(collate.Numeric, collate.IgnoreCase),
+	"StringBy(strings.ToLower)": hash.StringBy(strings.ToLower),
+}
+
+func TestStringEqHashLaws(t *testing.T) {
+	for name, eq := range stringEqHashes {
+		eq := eq
+		t.Run(name, func(t *testing.T) {
+			rapid.Check(t, func(t *rapid.T) {
+				values := genRelatedStrings().Draw(t, "values").([]string)
+				require.NoError(t, hash.CheckLaws(eq, values...))
+			})
+		})
+	}
+}
+
+func TestStringEqualities(t *testing.T) {
+	cases := []struct {
+		eq       hash.EqHash[string]
+		a, b     string
+		expected bool
+	}{
+		{hash.StringIgnoreCase(), "Kelvin", "KELVIN", true},
+		{hash.StringIgnoreCase(), "straße", "STRASSE", false},
+		{hash.StringCaseFold(), "straße", "STRASSE", true},
+		{hash.StringCaseFold(), "Café", "CAFÉ", true},
+		{hash.StringNFC(), "ﬁ", "fi", false},
+		{hash.StringNFKC(), "ﬁ", "fi", true},
+		{hash.StringNFKC(), "①", "1", true},
+		{hash.StringCollapseSpace(), "a  b", "a b", true},
+		{hash.StringCollapseSpace(), "ab", "a b", false},
+		{hash.StringCollation(), "é", "é", true},
+		{hash.StringCollation(), "e", "é", false},
+		{hash.StringCollation(collate.Loose), "e", "é", true},
+		{hash.StringCollation(collate.IgnoreCase), "a", "A", true},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.expected, c.eq.Equal(c.a, c.b), "%q and %q", c.a, c.b)
+		if c.expected {
+			require.Equal(t, c.eq.Hash(c.a), c.eq.Hash(c.b), "hashes of %q and %q", c.a, c.b)
+		}
+	}
+}
+
+func TestCheckLawsDetectsViolations(t *testing.T) {
+	inconsistent := hash.Fun[string]{
+		Eq: strings.EqualFold,
+		H:  hash.String().Hash,
+	}
+	err := hash.CheckLaws[string](inconsistent, "a", "b", "A")
+	require.ErrorIs(t, err, hash.ErrLawViolated)
+	require.NoError(t, hash.CheckLaws(hash.StringIgnoreCase(), "a", "b", "A"))
+}
@@ -0,0 +1,7 @@
+/*
+Package fingertree implements a persistent 2-3 finger tree annotated with sizes.
+
+It is used internally to implement sequence data structures with efficient access to both ends.
+See Hinze and Paterson: "Finger trees: a simple general-purpose data structure".
+*/
+package fingertree
@@ -0,0 +1,404 @@
+package fingertree
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// Tree is a persistent sequence.
+// Adding and removing elements at both ends takes amortized constant time.
+// Indexing, splitting and concatenation take logarithmic time.
+// The zero value is the empty tree.
+type Tree[T any] struct {
+	root *tree[T]
+}
+
+// item is an element stored in the tree.
+// On the top level items are leaves, on deeper levels items are nodes containing items of the level above.
+type item[T any] interface {
+	size() int
+}
+
+type leaf[T any] struct {
+	value T
+}
+
+type node[T any] struct {
+	// 2 or 3 children
+	children []item[T]
+	sz       int
+}
+
+// tree is either empty (nil), a single item (prefix == nil) or a deep tree.
+type tree[T any] struct {
+	sz     int
+	single item[T]
+	// 1 to 4 items
+	prefix []item[T]
+	middle *tree[T]
+	// 1 to 4 items
+	suffix []item[T]
+}
+
+func (l leaf[T]) size() int {
+	return 1
+}
+
+func (n *node[T]) size() int {
+	return n.sz
+}
+
+func (t *tree[T]) size() int {
+	if t == nil {
+		return 0
+	}
+	return t.sz
+}
+
+func itemsSize[T any](items []item[T]) (res int) {
+	for _, x := range items {
+		res += x.size()
+	}
+	return
+}
+
+func newNode[T any](children ...item[T]) item[T] {
+	return &node[T]{
+		children: children,
+		sz:       itemsSize(children),
+	}
+}
+
+func single[T any](x item[T]) *tree[T] {
+	return &tree[T]{sz: x.size(), single: x}
+}
+
+func deep[T any](prefix []item[T], middle *tree[T], suffix []item[T]) *tree[T] {
+	return &tree[T]{
+		sz:     itemsSize(prefix) + middle.size() + itemsSize(suffix),
+		prefix: prefix,
+		middle: middle,
+		suffix: suffix,
+	}
+}
+
+func items[T any](xs ...item[T]) []item[T] {
+	return xs
+}
+
+func pushFront[T any](x item[T], t *tree[T]) *tree[T] {
+	if t == nil {
+		return single(x)
+	}
+	if t.prefix == nil {
+		return deep(items(x), nil, items(t.single))
+	}
+	if len(t.prefix) == 4 {
+		p := t.prefix
+		return deep(items(x, p[0]), pushFront(newNode(p[1], p[2], p[3]), t.middle), t.suffix)
+	}
+	prefix := make([]item[T], 0, len(t.prefix)+1)
+	prefix = append(prefix, x)
+	prefix = append(prefix, t.prefix...)
+	return deep(prefix, t.middle, t.suffix)
+}
+
+func pushBack[T any](t *tree[T], x item[T]) *tree[T] {
+	if t == nil {
+		return single(x)
+	}
+	if t.prefix == nil {
+		return deep(items(t.single), nil, items(x))
+	}
+	if len(t.suffix) == 4 {
+		s := t.suffix
+		return deep(t.prefix, pushBack(t.middle, newNode(s[0], s[1], s[2])), items(s[3], x))
+	}
+	suffix := make([]item[T], 0, len(t.suffix)+1)
+	suffix = append(suffix, t.suffix...)
+	suffix = append(suffix, x)
+	return deep(t.prefix, t.middle, suffix)
+}
+
+func fromItems[T any](xs []item[T]) (res *tree[T]) {
+	for _, x := range xs {
+		res = pushBack(res, x)
+	}
+	return
+}
+
+// viewFront returns the first item and the remaining tree
+func viewFront[T any](t *tree[T]) (item[T], *tree[T]) {
+	if t == nil {
+		return nil, nil
+	}
+	if t.prefix == nil {
+		return t.single, nil
+	}
+	return t.prefix[0], deepL(t.prefix[1:], t.middle, t.suffix)
+}
+
+// viewBack returns the last item and the remaining tree
+func viewBack[T any](t *tree[T]) (item[T], *tree[T]) {
+	if t == nil {
+		return nil, nil
+	}
+	if t.prefix == nil {
+		return t.single, nil
+	}
+	return t.suffix[len(t.suffix)-1], deepR(t.prefix, t.middle, t.suffix[:len(t.suffix)-1])
+}
+
+// deepL creates a deep tree where the prefix may be empty
+func deepL[T any](prefix []item[T], middle *tree[T], suffix []item[T]) *tree[T] {
+	if len(prefix) > 0 {
+		return deep(prefix, middle, suffix)
+	}
+	if middle == nil {
+		return fromItems(suffix)
+	}
+	n, rest := viewFront(middle)
+	return deep(n.(*node[T]).children, rest, suffix)
+}
+
+// deepR creates a deep tree where the suffix may be empty
+func deepR[T any](prefix []item[T], middle *tree[T], suffix []item[T]) *tree[T] {
+	if len(suffix) > 0 {
+		return deep(prefix, middle, suffix)
+	}
+	if middle == nil {
+		return fromItems(prefix)
+	}
+	n, rest := viewBack(middle)
+	return deep(prefix, rest, n.(*node[T]).children)
+}
+
+// app3 concatenates two trees with some items in between
+func app3[T any](a *tree[T], xs []item[T], b *tree[T]) *tree[T] {
+	if a == nil {
+		for i := len(xs) - 1; i >= 0; i-- {
+			b = pushFront(xs[i], b)
+		}
+		return b
+	}
+	if b == nil {
+		for _, x := range xs {
+			a = pushBack(a, x)
+		}
+		return a
+	}
+	if a.prefix == nil {
+		return pushFront(a.single, app3(nil, xs, b))
+	}
+	if b.prefix == nil {
+		return pushBack(app3(a, xs, nil), b.single)
+	}
+	between := make([]item[T], 0, len(a.suffix)+len(xs)+len(b.prefix))
+	between = append(between, a.suffix...)
+	between = append(between, xs...)
+	between = append(between, b.prefix...)
+	return deep(a.prefix, app3(a.middle, nodes(between), b.middle), b.suffix)
+}
+
+// nodes groups 2 or more items into nodes with 2 or 3 children
+func nodes[T any](xs []item[T]) []item[T] {
+	res := make([]item[T], 0, len(xs)/2)
+	for len(xs) > 0 {
+		switch len(xs) {
+		case 2, 4:
+			res = append(res, newNode(xs[0], xs[1]))
+			xs = xs[2:]
+		default:
+			res = append(res, newNode(xs[0], xs[1], xs[2]))
+			xs = xs[3:]
+		}
+	}
+	return res
+}
+
+// lookupItems finds the item containing index i.
+// Returns the item and the index relative to the item.
+func lookupItems[T any](i int, xs []item[T]) (item[T], int) {
+	for _, x := range xs {
+		if i < x.size() {
+			return x, i
+		}
+		i -= x.size()
+	}
+	panic(fmt.Errorf("index out of range"))
+}
+
+// lookupTree finds the item containing index i.
+// Returns the item and the index relative to the item.
+func lookupTree[T any](i int, t *tree[T]) (item[T], int) {
+	if t.prefix == nil {
+		return t.single, i
+	}
+	if s := itemsSize(t.prefix); i < s {
+		return lookupItems(i, t.prefix)
+	} else {
+		i -= s
+	}
+	if s := t.middle.size(); i < s {
+		return lookupTree(i, t.middle)
+	} else {
+		i -= s
+	}
+	return lookupItems(i, t.suffix)
+}
+
+// splitItems splits the items at the item containing index i.
+// Returns the items before, the item containing i, the index relative to that item, and the items after.
+func splitItems[T any](i int, xs []item[T]) ([]item[T], item[T], int, []item[T]) {
+	for k, x := range xs {
+		if i < x.size() {
+			return xs[:k:k], x, i, xs[k+1:]
+		}
+		i -= x.size()
+	}
+	panic(fmt.Errorf("index out of range"))
+}
+
+// splitTree splits the tree at the item containing index i.
+// Returns the tree before, the item containing i, the index relative to that item, and the tree after.
+func splitTree[T any](i int, t *tree[T]) (*tree[T], item[T], int, *tree[T]) {
+	if t.prefix == nil {
+		return nil, t.single, i, nil
+	}
+	if s := itemsSize(t.prefix); i < s {
+		l, x, j, r := splitItems(i, t.prefix)
+		return fromItems(l), x, j, deepL(r, t.middle, t.suffix)
+	} else {
+		i -= s
+	}
+	if s := t.middle.size(); i < s {
+		ml, n, j, mr := splitTree(i, t.middle)
+		l, x, j, r := splitItems(j, n.(*node[T]).children)
+		return deepR(t.prefix, ml, l), x, j, deepL(r, mr, t.suffix)
+	} else {
+		i -= s
+	}
+	l, x, j, r := splitItems(i, t.suffix)
+	return deepR(t.prefix, t.middle, l), x, j, fromItems(r)
+}
+
+// New creates a tree from the given elements
+func New[T any](elems ...T) Tree[T] {
+	var res *tree[T]
+	for _, x := range elems {
+		res = pushBack[T](res, leaf[T]{x})
+	}
+	return Tree[T]{res}
+}
+
+// Size returns the number of elements in the tree
+func (t Tree[T]) Size() int {
+	return t.root.size()
+}
+
+// PushFront adds an element at the front
+func (t Tree[T]) PushFront(x T) Tree[T] {
+	return Tree[T]{pushFront[T](leaf[T]{x}, t.root)}
+}
+
+// PushBack adds an element at the back
+func (t Tree[T]) PushBack(x T) Tree[T] {
+	return Tree[T]{pushBack[T](t.root, leaf[T]{x})}
+}
+
+// PopFront returns the first element and the remaining tree.
+// Returns false if the tree is empty.
+func (t Tree[T]) PopFront() (T, Tree[T], bool) {
+	x, rest := viewFront(t.root)
+	if x == nil {
+		return zero.Value[T](), t, false
+	}
+	return x.(leaf[T]).value, Tree[T]{rest}, true
+}
+
+// PopBack returns the last element and the remaining tree.
+// Returns false if the tree is empty.
+func (t Tree[T]) PopBack() (T, Tree[T], bool) {
+	x, rest := viewBack(t.root)
+	if x == nil {
+		return zero.Value[T](), t, false
+	}
+	return x.(leaf[T]).value, Tree[T]{rest}, true
+}
+
+// Concat appends the other tree to this tree
+func (t Tree[T]) Concat(other Tree[T]) Tree[T] {
+	return Tree[T]{app3(t.root, nil, other.root)}
+}
+
+// At returns the element at index i.
+// Panics if the index is out of range.
+func (t Tree[T]) At(i int) T {
+	if i < 0 || i >= t.Size() {
+		panic(fmt.Errorf("index %d out of range for sequence of size %d", i, t.Size()))
+	}
+	x, i := lookupTree(i, t.root)
+	for {
+		switch n := x.(type) {
+		case leaf[T]:
+			return n.value
+		case *node[T]:
+			x, i = lookupItems(i, n.children)
+		}
+	}
+}
+
+// SplitAt splits the tree into the first i elements and the remaining elements.
+func (t Tree[T]) SplitAt(i int) (Tree[T], Tree[T]) {
+	if i <= 0 {
+		return Tree[T]{}, t
+	}
+	if i >= t.Size() {
+		return t, Tree[T]{}
+	}
+	l, x, j, r := splitTree(i, t.root)
+	// the split item is a leaf on the top level, so the index relative to it must be 0
+	if j != 0 {
+		panic(fmt.Errorf("invalid split at index %d", j))
+	}
+	return Tree[T]{l}, Tree[T]{pushFront(x, r)}
+}
+
+// Iterator returns a function that returns the elements from front to back
+func (t Tree[T]) Iterator() func() (T, bool) {
+	// stack of remaining work, where each entry is an item or a tree
+	var stack []any
+	if t.root != nil {
+		stack = append(stack, t.root)
+	}
+	return func() (T, bool) {
+		for len(stack) > 0 {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			switch x := top.(type) {
+			case leaf[T]:
+				return x.value, true
+			case *node[T]:
+				for i := len(x.children) - 1; i >= 0; i-- {
+					stack = append(stack, x.children[i])
+				}
+			case *tree[T]:
+				if x.prefix == nil {
+					stack = append(stack, x.single)
+					continue
+				}
+				for i := len(x.suffix) - 1; i >= 0; i-- {
+					stack = append(stack, x.suffix[i])
+				}
+				if x.middle != nil {
+					stack = append(stack, x.middle)
+				}
+				for i := len(x.prefix) - 1; i >= 0; i-- {
+					stack = append(stack, x.prefix[i])
+				}
+			}
+		}
+		return zero.Value[T](), false
+	}
+}
@@ -0,0 +1,14 @@
+/*
+Package intervals implements immutable sets and maps of intervals.
+
+An Interval is half-open: it contains all values x with Start <= x < End.
+The endpoints can have any type with an ordering given as a less function,
+for numbers the ordering Less can be used.
+
+IntervalSet represents a union of intervals, which is kept in a normalised form of disjoint, non-adjacent intervals.
+IntervalMap maps possibly overlapping intervals to values and supports efficient overlap queries.
+
+Both are backed by a persistent AVL tree, so that updates take O(log n) time (plus the number of merged or removed intervals)
+and old versions remain valid.
+*/
+package intervals
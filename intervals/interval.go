@@ -0,0 +1,63 @@
+package intervals
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/iterable"
+)
+
+// Interval is a half-open interval containing all values x with Start <= x < End.
+// An interval where End is not greater than Start is empty.
+type Interval[T any] struct {
+	Start T
+	End   T
+}
+
+// Of is a shorthand for creating an Interval
+func Of[T any](start, end T) Interval[T] {
+	return Interval[T]{Start: start, End: end}
+}
+
+// Less is the natural ordering on numbers
+func Less[N iterable.Number](a, b N) bool {
+	return a < b
+}
+
+func (i Interval[T]) String() string {
+	return fmt.Sprintf("[%v, %v)", i.Start, i.End)
+}
+
+// empty checks whether the interval contains no values
+func (i Interval[T]) empty(less func(a, b T) bool) bool {
+	return !less(i.Start, i.End)
+}
+
+// compareIntervals orders intervals by start and then by end
+func compareIntervals[T any](less func(a, b T) bool, a, b Interval[T]) int {
+	switch {
+	case less(a.Start, b.Start):
+		return -1
+	case less(b.Start, a.Start):
+		return 1
+	case less(a.End, b.End):
+		return -1
+	case less(b.End, a.End):
+		return 1
+	default:
+		return 0
+	}
+}
+
+func minT[T any](less func(a, b T) bool, a, b T) T {
+	if less(b, a) {
+		return b
+	}
+	return a
+}
+
+func maxT[T any](less func(a, b T) bool, a, b T) T {
+	if less(a, b) {
+		return b
+	}
+	return a
+}
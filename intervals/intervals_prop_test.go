@@ -0,0 +1,163 @@
+package intervals
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+const domain = 20
+
+func genInterval(t *rapid.T, label string) Interval[int] {
+	start := rapid.IntRange(0, domain).Draw(t, label+"Start").(int)
+	end := rapid.IntRange(0, domain).Draw(t, label+"End").(int)
+	return Of(start, end)
+}
+
+// setModel represents a set as a bitmap over the domain
+type setModel [domain]bool
+
+func checkSet(t *rapid.T, s IntervalSet[int], model setModel) {
+	require.NoError(t, checkInvariant(s.less, s.root))
+	// normalised: sorted, non-empty, non-adjacent
+	ivs := iterable.ToSlice[Interval[int]](s)
+	require.Equal(t, len(ivs), s.Size())
+	for i, iv := range ivs {
+		require.Less(t, iv.Start, iv.End, "interval %v must not be empty", iv)
+		if i > 0 {
+			require.Less(t, ivs[i-1].End, iv.Start, "intervals %v and %v must be separated", ivs[i-1], iv)
+		}
+	}
+	for x := 0; x < domain; x++ {
+		require.Equal(t, model[x], s.Contains(x), "contains %d in %v", x, s)
+	}
+}
+
+func modelOf(s IntervalSet[int]) setModel {
+	var m setModel
+	for x := 0; x < domain; x++ {
+		m[x] = s.Contains(x)
+	}
+	return m
+}
+
+func TestIntervalSetOperations(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := NumberSet[int]()
+		var model setModel
+		n := rapid.IntRange(0, 30).Draw(t, "n").(int)
+		for i := 0; i < n; i++ {
+			iv := genInterval(t, "iv")
+			switch rapid.IntRange(0, 6).Draw(t, "op").(int) {
+			case 0, 1:
+				s = s.Add(iv)
+				for x := iv.Start; x < iv.End; x++ {
+					model[x] = true
+				}
+			case 2:
+				s = s.Remove(iv)
+				for x := iv.Start; x < iv.End; x++ {
+					model[x] = false
+				}
+			case 3:
+				expected := false
+				all := true
+				for x := iv.Start; x < iv.End; x++ {
+					expected = expected || model[x]
+					all = all && model[x]
+				}
+				require.Equal(t, expected, s.Overlaps(iv), "overlaps %v", iv)
+				require.Equal(t, all, s.ContainsInterval(iv), "contains interval %v", iv)
+			case 4:
+				c := s.Complement(iv)
+				var expected setModel
+				for x := iv.Start; x < iv.End; x++ {
+					expected[x] = !model[x]
+				}
+				checkSet(t, c, expected)
+				require.True(t, c.Intersect(s).Empty())
+			case 5:
+				other := NumberSet(genInterval(t, "a"), genInterval(t, "b"))
+				om := modelOf(other)
+				var union, inter, minus setModel
+				for x := 0; x < domain; x++ {
+					union[x] = model[x] || om[x]
+					inter[x] = model[x] && om[x]
+					minus[x] = model[x] && !om[x]
+				}
+				checkSet(t, s.Union(other), union)
+				checkSet(t, s.Intersect(other), inter)
+				checkSet(t, s.Minus(other), minus)
+			case 6:
+				rebuilt := NumberSet(iterable.ToSlice[Interval[int]](s)...)
+				require.True(t, s.Equal(rebuilt))
+			}
+			checkSet(t, s, model)
+		}
+	})
+}
+
+type mapEntry = dict.Entry[Interval[int], int]
+
+func sortEntries(entries []mapEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return compareIntervals(Less[int], entries[i].Key, entries[j].Key) < 0
+	})
+}
+
+func TestIntervalMapOperations(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		m := NumberMap[int, int]()
+		model := make(map[Interval[int]]int)
+		n := rapid.IntRange(0, 30).Draw(t, "n").(int)
+		for i := 0; i < n; i++ {
+			iv := genInterval(t, "iv")
+			switch rapid.IntRange(0, 3).Draw(t, "op").(int) {
+			case 0, 1:
+				v := rapid.IntRange(0, 100).Draw(t, "v").(int)
+				m = m.Set(iv, v)
+				model[iv] = v
+			case 2:
+				m = m.Remove(iv)
+				delete(model, iv)
+			case 3:
+				expected := []mapEntry{}
+				for k, v := range model {
+					if k.Start < iv.End && iv.Start < k.End && k.Start < k.End && iv.Start < iv.End {
+						expected = append(expected, dict.E(k, v))
+					}
+				}
+				sortEntries(expected)
+				actual := append([]mapEntry{}, iterable.ToSlice(m.Overlapping(iv))...)
+				require.Equal(t, expected, actual, "overlapping %v", iv)
+				require.Equal(t, len(expected) > 0, m.Overlaps(iv))
+
+				expected = []mapEntry{}
+				for k, v := range model {
+					if k.Start <= iv.Start && iv.Start < k.End {
+						expected = append(expected, dict.E(k, v))
+					}
+				}
+				sortEntries(expected)
+				actual = append([]mapEntry{}, iterable.ToSlice(m.Containing(iv.Start))...)
+				require.Equal(t, expected, actual, "containing %v", iv.Start)
+			}
+			require.NoError(t, checkInvariant(m.less, m.root))
+			require.Equal(t, len(model), m.Size())
+			v, ok := m.Get(iv)
+			mv, mok := model[iv]
+			require.Equal(t, mok, ok)
+			require.Equal(t, mv, v)
+		}
+		expected := []mapEntry{}
+		for k, v := range model {
+			expected = append(expected, dict.E(k, v))
+		}
+		sortEntries(expected)
+		require.Equal(t, expected, append([]mapEntry{}, iterable.ToSlice[mapEntry](m)...))
+	})
+}
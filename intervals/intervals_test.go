@@ -0,0 +1,64 @@
+package intervals_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/intervals"
+	"github.com/peterzeller/go-fun/iterable"
+)
+
+func ExampleIntervalSet() {
+	s := intervals.NumberSet(intervals.Of(1, 3), intervals.Of(5, 8))
+	fmt.Printf("%v\n", s)
+	s = s.Add(intervals.Of(3, 5))
+	fmt.Printf("after add: %v\n", s)
+	s = s.Remove(intervals.Of(2, 4))
+	fmt.Printf("after remove: %v\n", s)
+	fmt.Printf("contains 4: %v, contains 2: %v\n", s.Contains(4), s.Contains(2))
+	fmt.Printf("overlaps [0, 2): %v\n", s.Overlaps(intervals.Of(0, 2)))
+	fmt.Printf("complement: %v\n", s.Complement(intervals.Of(0, 10)))
+	// output:
+	// [[1, 3), [5, 8)]
+	// after add: [[1, 8)]
+	// after remove: [[1, 2), [4, 8)]
+	// contains 4: true, contains 2: false
+	// overlaps [0, 2): true
+	// complement: [[0, 1), [2, 4), [8, 10)]
+}
+
+func ExampleNewSet() {
+	day := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+	reserved := intervals.NewSet(func(a, b time.Time) bool { return a.Before(b) },
+		intervals.Of(at(9), at(11)),
+		intervals.Of(at(10), at(12)),
+		intervals.Of(at(14), at(15)))
+	free := reserved.Complement(intervals.Of(at(8), at(18)))
+	for it := free.Iterator(); ; {
+		iv, ok := it.Next()
+		if !ok {
+			break
+		}
+		fmt.Printf("free from %v to %v\n", iv.Start.Hour(), iv.End.Hour())
+	}
+	// output:
+	// free from 8 to 9
+	// free from 12 to 14
+	// free from 15 to 18
+}
+
+func ExampleIntervalMap() {
+	m := intervals.NumberMap(
+		dict.E(intervals.Of(0, 10), "a"),
+		dict.E(intervals.Of(5, 15), "b"),
+		dict.E(intervals.Of(20, 30), "c"))
+	fmt.Printf("containing 7: %v\n", iterable.ToSlice(m.Containing(7)))
+	fmt.Printf("overlapping [12, 25): %v\n", iterable.ToSlice(m.Overlapping(intervals.Of(12, 25))))
+	fmt.Printf("coverage: %v\n", m.Coverage())
+	// output:
+	// containing 7: [[0, 10) -> a [5, 15) -> b]
+	// overlapping [12, 25): [[5, 15) -> b [20, 30) -> c]
+	// coverage: [[0, 15), [20, 30)]
+}
@@ -0,0 +1,120 @@
+package intervals
+
+import (
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// IntervalMap is an immutable map from intervals to values.
+// Unlike IntervalSet, the intervals may overlap and are not merged.
+// Two entries have the same key if their intervals have equal start and end points.
+type IntervalMap[T, V any] struct {
+	root *tree[T, V]
+	less func(a, b T) bool
+}
+
+// NewMap creates a new map with the given entries.
+// The less function determines the order of the endpoints.
+func NewMap[T, V any](less func(a, b T) bool, entries ...dict.Entry[Interval[T], V]) IntervalMap[T, V] {
+	m := IntervalMap[T, V]{less: less}
+	for _, e := range entries {
+		m = m.Set(e.Key, e.Value)
+	}
+	return m
+}
+
+// NumberMap creates a new map with intervals of numbers.
+func NumberMap[N iterable.Number, V any](entries ...dict.Entry[Interval[N], V]) IntervalMap[N, V] {
+	return NewMap(Less[N], entries...)
+}
+
+// Set returns a map where the given interval is mapped to the given value.
+func (m IntervalMap[T, V]) Set(iv Interval[T], value V) IntervalMap[T, V] {
+	return IntervalMap[T, V]{
+		root: insert(m.less, m.root, iv, value),
+		less: m.less,
+	}
+}
+
+// Remove returns a map without the entry for the given interval.
+func (m IntervalMap[T, V]) Remove(iv Interval[T]) IntervalMap[T, V] {
+	root, ok := remove(m.less, m.root, iv)
+	if !ok {
+		return m
+	}
+	return IntervalMap[T, V]{root: root, less: m.less}
+}
+
+// Get returns the value for exactly the given interval.
+func (m IntervalMap[T, V]) Get(iv Interval[T]) (V, bool) {
+	n := get(m.less, m.root, iv)
+	if n == nil {
+		return zero.Value[V](), false
+	}
+	return n.value, true
+}
+
+// ContainsKey checks whether the map has an entry for exactly the given interval.
+func (m IntervalMap[T, V]) ContainsKey(iv Interval[T]) bool {
+	return get(m.less, m.root, iv) != nil
+}
+
+// Overlapping returns the entries with intervals that overlap the given interval, ordered by their intervals.
+// Empty intervals do not overlap with any interval.
+func (m IntervalMap[T, V]) Overlapping(iv Interval[T]) iterable.Iterable[dict.Entry[Interval[T], V]] {
+	return iterable.IterableFun[dict.Entry[Interval[T], V]](func() iterable.Iterator[dict.Entry[Interval[T], V]] {
+		return entryIterator(overlapping(m.less, m.root, iv), toEntry[T, V])
+	})
+}
+
+// Containing returns the entries with intervals that contain the given value, ordered by their intervals.
+func (m IntervalMap[T, V]) Containing(x T) iterable.Iterable[dict.Entry[Interval[T], V]] {
+	return iterable.IterableFun[dict.Entry[Interval[T], V]](func() iterable.Iterator[dict.Entry[Interval[T], V]] {
+		it := search(m.root,
+			func(start T) bool { return !m.less(x, start) },
+			func(end T) bool { return m.less(x, end) })
+		return entryIterator(it, toEntry[T, V])
+	})
+}
+
+// Overlaps checks whether some interval in the map overlaps the given interval.
+func (m IntervalMap[T, V]) Overlaps(iv Interval[T]) bool {
+	_, ok := overlapping(m.less, m.root, iv).Next()
+	return ok
+}
+
+// Size returns the number of entries in the map.
+func (m IntervalMap[T, V]) Size() int {
+	return size(m.root)
+}
+
+// Iterator returns the entries ordered by the start and then the end of the intervals.
+func (m IntervalMap[T, V]) Iterator() iterable.Iterator[dict.Entry[Interval[T], V]] {
+	return entryIterator(all(m.root), toEntry[T, V])
+}
+
+// Keys returns the intervals in the map in ascending order.
+func (m IntervalMap[T, V]) Keys() iterable.Iterable[Interval[T]] {
+	return iterable.Map[dict.Entry[Interval[T], V], Interval[T]](m, func(e dict.Entry[Interval[T], V]) Interval[T] { return e.Key })
+}
+
+// Coverage returns the set of all values covered by some interval in the map.
+func (m IntervalMap[T, V]) Coverage() IntervalSet[T] {
+	s := NewSet(m.less)
+	for it := m.Keys().Iterator(); ; {
+		iv, ok := it.Next()
+		if !ok {
+			return s
+		}
+		s = s.Add(iv)
+	}
+}
+
+func (m IntervalMap[T, V]) String() string {
+	return iterable.String[dict.Entry[Interval[T], V]](m)
+}
+
+func toEntry[T, V any](n *tree[T, V]) dict.Entry[Interval[T], V] {
+	return dict.E(n.iv, n.value)
+}
@@ -0,0 +1,232 @@
+package intervals
+
+import (
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/iterable"
+)
+
+// IntervalSet is an immutable set of values, represented as a union of intervals.
+// The intervals are kept disjoint and non-adjacent, so each set has a unique representation.
+type IntervalSet[T any] struct {
+	root *tree[T, struct{}]
+	less func(a, b T) bool
+}
+
+var _ equality.Equal[IntervalSet[int]] = IntervalSet[int]{}
+
+// NewSet creates a new set containing the union of the given intervals.
+// The less function determines the order of the endpoints.
+func NewSet[T any](less func(a, b T) bool, intervals ...Interval[T]) IntervalSet[T] {
+	s := IntervalSet[T]{less: less}
+	for _, iv := range intervals {
+		s = s.Add(iv)
+	}
+	return s
+}
+
+// NumberSet creates a new set of numbers containing the union of the given intervals.
+func NumberSet[N iterable.Number](intervals ...Interval[N]) IntervalSet[N] {
+	return NewSet(Less[N], intervals...)
+}
+
+// fromSortedIntervals creates a set from normalised intervals
+func (s IntervalSet[T]) fromSortedIntervals(ivs []Interval[T]) IntervalSet[T] {
+	return IntervalSet[T]{
+		root: fromSorted(s.less, ivs, make([]struct{}, len(ivs))),
+		less: s.less,
+	}
+}
+
+// Add returns a set that additionally contains all values in the given interval.
+// Intervals that overlap or touch the new interval are merged with it.
+func (s IntervalSet[T]) Add(iv Interval[T]) IntervalSet[T] {
+	if iv.empty(s.less) {
+		return s
+	}
+	merged := iv
+	root := s.root
+	// find all overlapping or adjacent intervals
+	it := search(s.root,
+		func(start T) bool { return !s.less(iv.End, start) },
+		func(end T) bool { return !s.less(end, iv.Start) })
+	for n, ok := it.Next(); ok; n, ok = it.Next() {
+		merged.Start = minT(s.less, merged.Start, n.iv.Start)
+		merged.End = maxT(s.less, merged.End, n.iv.End)
+		root, _ = remove(s.less, root, n.iv)
+	}
+	return IntervalSet[T]{
+		root: insert(s.less, root, merged, struct{}{}),
+		less: s.less,
+	}
+}
+
+// Remove returns a set that contains none of the values in the given interval.
+func (s IntervalSet[T]) Remove(iv Interval[T]) IntervalSet[T] {
+	if iv.empty(s.less) {
+		return s
+	}
+	root := s.root
+	changed := false
+	for it := overlapping(s.less, s.root, iv); ; {
+		n, ok := it.Next()
+		if !ok {
+			break
+		}
+		changed = true
+		root, _ = remove(s.less, root, n.iv)
+		if s.less(n.iv.Start, iv.Start) {
+			root = insert(s.less, root, Of(n.iv.Start, iv.Start), struct{}{})
+		}
+		if s.less(iv.End, n.iv.End) {
+			root = insert(s.less, root, Of(iv.End, n.iv.End), struct{}{})
+		}
+	}
+	if !changed {
+		return s
+	}
+	return IntervalSet[T]{root: root, less: s.less}
+}
+
+// Union returns a set containing the values from both sets.
+func (s IntervalSet[T]) Union(other IntervalSet[T]) IntervalSet[T] {
+	if size(other.root) > size(s.root) {
+		s, other = other, s
+	}
+	for it := other.Iterator(); ; {
+		iv, ok := it.Next()
+		if !ok {
+			return s
+		}
+		s = s.Add(iv)
+	}
+}
+
+// Minus returns a set containing the values from this set that are not in the other set.
+func (s IntervalSet[T]) Minus(other IntervalSet[T]) IntervalSet[T] {
+	for it := other.Iterator(); ; {
+		iv, ok := it.Next()
+		if !ok {
+			return s
+		}
+		s = s.Remove(iv)
+	}
+}
+
+// Intersect returns a set containing the values that are in both sets.
+func (s IntervalSet[T]) Intersect(other IntervalSet[T]) IntervalSet[T] {
+	var res []Interval[T]
+	for it := other.Iterator(); ; {
+		iv, ok := it.Next()
+		if !ok {
+			break
+		}
+		for it2 := overlapping(s.less, s.root, iv); ; {
+			n, ok := it2.Next()
+			if !ok {
+				break
+			}
+			res = append(res, Of(maxT(s.less, iv.Start, n.iv.Start), minT(s.less, iv.End, n.iv.End)))
+		}
+	}
+	return s.fromSortedIntervals(res)
+}
+
+// Complement returns the values within the given bounds that are not in the set.
+func (s IntervalSet[T]) Complement(within Interval[T]) IntervalSet[T] {
+	var res []Interval[T]
+	if within.empty(s.less) {
+		return s.fromSortedIntervals(res)
+	}
+	current := within.Start
+	for it := overlapping(s.less, s.root, within); ; {
+		n, ok := it.Next()
+		if !ok {
+			break
+		}
+		if s.less(current, n.iv.Start) {
+			res = append(res, Of(current, n.iv.Start))
+		}
+		current = n.iv.End
+	}
+	if s.less(current, within.End) {
+		res = append(res, Of(current, within.End))
+	}
+	return s.fromSortedIntervals(res)
+}
+
+// Contains checks whether the given value is in the set.
+func (s IntervalSet[T]) Contains(x T) bool {
+	_, ok := s.Find(x)
+	return ok
+}
+
+// Find returns the interval containing the given value.
+// Returns false if the value is not in the set.
+func (s IntervalSet[T]) Find(x T) (Interval[T], bool) {
+	it := search(s.root,
+		func(start T) bool { return !s.less(x, start) },
+		func(end T) bool { return s.less(x, end) })
+	n, ok := it.Next()
+	if !ok {
+		return Interval[T]{}, false
+	}
+	return n.iv, true
+}
+
+// ContainsInterval checks whether all values of the given interval are in the set.
+// Empty intervals are always contained.
+func (s IntervalSet[T]) ContainsInterval(iv Interval[T]) bool {
+	if iv.empty(s.less) {
+		return true
+	}
+	found, ok := s.Find(iv.Start)
+	return ok && !s.less(found.End, iv.End)
+}
+
+// Overlaps checks whether some value of the given interval is in the set.
+func (s IntervalSet[T]) Overlaps(iv Interval[T]) bool {
+	if iv.empty(s.less) {
+		return false
+	}
+	_, ok := overlapping(s.less, s.root, iv).Next()
+	return ok
+}
+
+// Empty checks whether the set contains no values.
+func (s IntervalSet[T]) Empty() bool {
+	return s.root == nil
+}
+
+// Size returns the number of disjoint intervals in the set.
+func (s IntervalSet[T]) Size() int {
+	return size(s.root)
+}
+
+// Iterator returns the disjoint intervals of the set in ascending order.
+func (s IntervalSet[T]) Iterator() iterable.Iterator[Interval[T]] {
+	return entryIterator(all(s.root), func(n *tree[T, struct{}]) Interval[T] {
+		return n.iv
+	})
+}
+
+// Equal checks whether both sets contain the same values.
+func (s IntervalSet[T]) Equal(other IntervalSet[T]) bool {
+	if s.Size() != other.Size() {
+		return false
+	}
+	it1, it2 := s.Iterator(), other.Iterator()
+	for {
+		a, ok := it1.Next()
+		if !ok {
+			return true
+		}
+		b, _ := it2.Next()
+		if compareIntervals(s.less, a, b) != 0 {
+			return false
+		}
+	}
+}
+
+func (s IntervalSet[T]) String() string {
+	return iterable.String[Interval[T]](s)
+}
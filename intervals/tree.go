@@ -0,0 +1,257 @@
+package intervals
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// tree is a persistent AVL tree ordered by the intervals.
+// Each node is augmented with the maximum end point in its subtree, which is used to prune overlap queries.
+type tree[T, V any] struct {
+	iv     Interval[T]
+	value  V
+	left   *tree[T, V]
+	right  *tree[T, V]
+	height int
+	size   int
+	maxEnd T
+}
+
+func height[T, V any](n *tree[T, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func size[T, V any](n *tree[T, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// mk creates a new node and computes the cached fields
+func mk[T, V any](less func(a, b T) bool, iv Interval[T], value V, left, right *tree[T, V]) *tree[T, V] {
+	h := height(left)
+	if hr := height(right); hr > h {
+		h = hr
+	}
+	maxEnd := iv.End
+	if left != nil {
+		maxEnd = maxT(less, maxEnd, left.maxEnd)
+	}
+	if right != nil {
+		maxEnd = maxT(less, maxEnd, right.maxEnd)
+	}
+	return &tree[T, V]{
+		iv:     iv,
+		value:  value,
+		left:   left,
+		right:  right,
+		height: h + 1,
+		size:   size(left) + size(right) + 1,
+		maxEnd: maxEnd,
+	}
+}
+
+// balance creates a new node like mk, but performs rotations if the heights of the subtrees differ by more than one.
+func balance[T, V any](less func(a, b T) bool, iv Interval[T], value V, left, right *tree[T, V]) *tree[T, V] {
+	hl, hr := height(left), height(right)
+	if hl > hr+1 {
+		if height(left.left) >= height(left.right) {
+			return mk(less, left.iv, left.value, left.left, mk(less, iv, value, left.right, right))
+		}
+		lr := left.right
+		return mk(less, lr.iv, lr.value,
+			mk(less, left.iv, left.value, left.left, lr.left),
+			mk(less, iv, value, lr.right, right))
+	}
+	if hr > hl+1 {
+		if height(right.right) >= height(right.left) {
+			return mk(less, right.iv, right.value, mk(less, iv, value, left, right.left), right.right)
+		}
+		rl := right.left
+		return mk(less, rl.iv, rl.value,
+			mk(less, iv, value, left, rl.left),
+			mk(less, right.iv, right.value, rl.right, right.right))
+	}
+	return mk(less, iv, value, left, right)
+}
+
+// insert adds an entry to the tree, replacing the value if the interval is already present
+func insert[T, V any](less func(a, b T) bool, n *tree[T, V], iv Interval[T], value V) *tree[T, V] {
+	if n == nil {
+		return mk[T, V](less, iv, value, nil, nil)
+	}
+	switch c := compareIntervals(less, iv, n.iv); {
+	case c < 0:
+		return balance(less, n.iv, n.value, insert(less, n.left, iv, value), n.right)
+	case c > 0:
+		return balance(less, n.iv, n.value, n.left, insert(less, n.right, iv, value))
+	default:
+		return mk(less, iv, value, n.left, n.right)
+	}
+}
+
+// remove removes the entry for the given interval.
+// Returns false if the interval is not in the tree.
+func remove[T, V any](less func(a, b T) bool, n *tree[T, V], iv Interval[T]) (*tree[T, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	switch c := compareIntervals(less, iv, n.iv); {
+	case c < 0:
+		left, ok := remove(less, n.left, iv)
+		if !ok {
+			return n, false
+		}
+		return balance(less, n.iv, n.value, left, n.right), true
+	case c > 0:
+		right, ok := remove(less, n.right, iv)
+		if !ok {
+			return n, false
+		}
+		return balance(less, n.iv, n.value, n.left, right), true
+	default:
+		if n.left == nil {
+			return n.right, true
+		}
+		if n.right == nil {
+			return n.left, true
+		}
+		min, right := removeMin(less, n.right)
+		return balance(less, min.iv, min.value, n.left, right), true
+	}
+}
+
+// removeMin removes the smallest entry from a non-empty tree and returns it.
+func removeMin[T, V any](less func(a, b T) bool, n *tree[T, V]) (*tree[T, V], *tree[T, V]) {
+	if n.left == nil {
+		return n, n.right
+	}
+	min, left := removeMin(less, n.left)
+	return min, balance(less, n.iv, n.value, left, n.right)
+}
+
+// get finds the node for the given interval
+func get[T, V any](less func(a, b T) bool, n *tree[T, V], iv Interval[T]) *tree[T, V] {
+	for n != nil {
+		switch c := compareIntervals(less, iv, n.iv); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// fromSorted builds a balanced tree from sorted entries
+func fromSorted[T, V any](less func(a, b T) bool, ivs []Interval[T], values []V) *tree[T, V] {
+	if len(ivs) == 0 {
+		return nil
+	}
+	mid := len(ivs) / 2
+	return mk(less, ivs[mid], values[mid],
+		fromSorted(less, ivs[:mid], values[:mid]),
+		fromSorted(less, ivs[mid+1:], values[mid+1:]))
+}
+
+// search returns the nodes in order, for which endOk(node.iv.End) and startOk(node.iv.Start) hold.
+// The predicate endOk must be monotone (if it holds for x, it holds for all values greater than x)
+// and startOk must be antitone (if it holds for x, it holds for all values smaller than x).
+// Subtrees that cannot contain matching nodes are skipped.
+func search[T, V any](n *tree[T, V], startOk, endOk func(T) bool) iterable.Iterator[*tree[T, V]] {
+	var stack []*tree[T, V]
+	pushLeft := func(n *tree[T, V]) {
+		for n != nil && endOk(n.maxEnd) {
+			if startOk(n.iv.Start) {
+				stack = append(stack, n)
+			}
+			n = n.left
+		}
+	}
+	pushLeft(n)
+	return iterable.Fun[*tree[T, V]](func() (*tree[T, V], bool) {
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			pushLeft(n.right)
+			if endOk(n.iv.End) {
+				return n, true
+			}
+		}
+		return nil, false
+	})
+}
+
+// all returns all nodes in order
+func all[T, V any](n *tree[T, V]) iterable.Iterator[*tree[T, V]] {
+	always := func(T) bool { return true }
+	return search(n, always, always)
+}
+
+// overlapping returns the nodes with intervals overlapping the given interval in order.
+// Empty intervals do not overlap with any interval.
+func overlapping[T, V any](less func(a, b T) bool, n *tree[T, V], iv Interval[T]) iterable.Iterator[*tree[T, V]] {
+	if iv.empty(less) {
+		return iterable.Fun[*tree[T, V]](func() (*tree[T, V], bool) { return nil, false })
+	}
+	it := search(n,
+		func(start T) bool { return less(start, iv.End) },
+		func(end T) bool { return less(iv.Start, end) })
+	return iterable.Fun[*tree[T, V]](func() (*tree[T, V], bool) {
+		for {
+			n, ok := it.Next()
+			if !ok || !n.iv.empty(less) {
+				return n, ok
+			}
+		}
+	})
+}
+
+func entryIterator[T, V, R any](it iterable.Iterator[*tree[T, V]], f func(n *tree[T, V]) R) iterable.Iterator[R] {
+	return iterable.Fun[R](func() (R, bool) {
+		n, ok := it.Next()
+		if !ok {
+			return zero.Value[R](), false
+		}
+		return f(n), true
+	})
+}
+
+// checkInvariant checks the ordering, balance and cached fields of the tree
+func checkInvariant[T, V any](less func(a, b T) bool, n *tree[T, V]) error {
+	if n == nil {
+		return nil
+	}
+	if err := checkInvariant(less, n.left); err != nil {
+		return err
+	}
+	if err := checkInvariant(less, n.right); err != nil {
+		return err
+	}
+	if n.left != nil && compareIntervals(less, n.left.iv, n.iv) >= 0 {
+		return fmt.Errorf("left child %v not smaller than %v", n.left.iv, n.iv)
+	}
+	if n.right != nil && compareIntervals(less, n.iv, n.right.iv) >= 0 {
+		return fmt.Errorf("right child %v not greater than %v", n.right.iv, n.iv)
+	}
+	hl, hr := height(n.left), height(n.right)
+	if hl > hr+1 || hr > hl+1 {
+		return fmt.Errorf("unbalanced node %v: heights %d and %d", n.iv, hl, hr)
+	}
+	expected := mk(less, n.iv, n.value, n.left, n.right)
+	if expected.height != n.height || expected.size != n.size {
+		return fmt.Errorf("wrong cached height or size at %v", n.iv)
+	}
+	if less(expected.maxEnd, n.maxEnd) || less(n.maxEnd, expected.maxEnd) {
+		return fmt.Errorf("wrong maxEnd at %v: %v, expected %v", n.iv, n.maxEnd, expected.maxEnd)
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package iterable_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+func ExampleGroupAdjacent() {
+	groups := iterable.GroupAdjacent(iterable.New(1, 1, 2, 3, 3, 3, 1), equality.Default[int]())
+	fmt.Println(iterable.String(groups))
+	// output: [[1 1], [2], [3 3 3], [1]]
+}
+
+func ExampleCombinations() {
+	fmt.Println(iterable.String(iterable.Combinations(iterable.New("a", "b", "c", "d"), 2)))
+	// output: [[a b], [a c], [a d], [b c], [b d], [c d]]
+}
+
+func ExamplePermutations() {
+	fmt.Println(iterable.String(iterable.Permutations(iterable.New(1, 2, 3))))
+	// output: [[1 2 3], [1 3 2], [2 1 3], [2 3 1], [3 1 2], [3 2 1]]
+}
+
+func smallSlice() *rapid.Generator {
+	return rapid.SliceOfN(rapid.IntRange(0, 5), 0, 20)
+}
+
+// collidingHash is an EqHash for ints where many values have the same hash
+var collidingHash hash.EqHash[int] = hash.Fun[int]{
+	Eq: func(a, b int) bool { return a == b },
+	H:  func(a int) int64 { return int64(a % 2) },
+}
+
+func TestReverse(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := smallSlice().Draw(t, "s").([]int)
+		expected := make([]int, len(s))
+		for i, x := range s {
+			expected[len(s)-1-i] = x
+		}
+		require.Equal(t, expected, append([]int{}, iterable.ToSlice(iterable.Reverse(iterable.FromSlice(s)))...))
+		filtered := iterable.Filter(iterable.FromSlice(s), func(int) bool { return true })
+		require.Equal(t, expected, append([]int{}, iterable.ToSlice(iterable.Reverse(filtered))...))
+		checkRandomAccess(t, iterable.Reverse(iterable.FromSlice(s)))
+	})
+}
+
+func TestSortBy(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := smallSlice().Draw(t, "s").([]int)
+		type entry struct{ key, pos int }
+		entries := make([]entry, len(s))
+		for i, x := range s {
+			entries[i] = entry{x, i}
+		}
+		sorted := iterable.ToSlice(iterable.SortBy(iterable.FromSlice(entries), func(a, b entry) bool {
+			return a.key < b.key
+		}))
+		require.Len(t, sorted, len(s))
+		for i := 1; i < len(sorted); i++ {
+			a, b := sorted[i-1], sorted[i]
+			require.True(t, a.key < b.key || a.key == b.key && a.pos < b.pos, "%v before %v", a, b)
+		}
+		// input is not modified
+		for i, e := range entries {
+			require.Equal(t, i, e.pos)
+		}
+	})
+}
+
+func TestGroupAdjacent(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := smallSlice().Draw(t, "s").([]int)
+		groups := iterable.ToSlice(iterable.GroupAdjacent(iterable.FromSlice(s), equality.Default[int]()))
+		var flat []int
+		for i, g := range groups {
+			require.NotEmpty(t, g)
+			for _, x := range g {
+				require.Equal(t, g[0], x)
+			}
+			if i > 0 {
+				require.NotEqual(t, groups[i-1][0], g[0])
+			}
+			flat = append(flat, g...)
+		}
+		require.Equal(t, s, append([]int{}, flat...))
+	})
+}
+
+func TestDistinct(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := smallSlice().Draw(t, "s").([]int)
+		expected := []int{}
+		seen := make(map[int]bool)
+		for _, x := range s {
+			if !seen[x] {
+				seen[x] = true
+				expected = append(expected, x)
+			}
+		}
+		require.Equal(t, expected, append([]int{}, iterable.ToSlice(iterable.Distinct(iterable.FromSlice(s), collidingHash))...))
+	})
+}
+
+func TestPartition(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		s := smallSlice().Draw(t, "s").([]int)
+		even, odd := iterable.Partition(iterable.FromSlice(s), func(x int) bool { return x%2 == 0 })
+		expectedEven, expectedOdd := []int{}, []int{}
+		for _, x := range s {
+			if x%2 == 0 {
+				expectedEven = append(expectedEven, x)
+			} else {
+				expectedOdd = append(expectedOdd, x)
+			}
+		}
+		require.Equal(t, expectedEven, append([]int{}, iterable.ToSlice(even)...))
+		require.Equal(t, expectedOdd, append([]int{}, iterable.ToSlice(odd)...))
+	})
+}
+
+func TestFlatten(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		ss := rapid.SliceOfN(smallSlice(), 0, 5).Draw(t, "ss").([][]int)
+		expected := []int{}
+		var its []iterable.Iterable[int]
+		for _, s := range ss {
+			expected = append(expected, s...)
+			its = append(its, iterable.FromSlice(s))
+		}
+		require.Equal(t, expected, append([]int{}, iterable.ToSlice(iterable.Flatten(iterable.FromSlice(its)))...))
+	})
+}
+
+func TestProduct(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		a := smallSlice().Draw(t, "a").([]int)
+		b := rapid.SliceOfN(rapid.String(), 0, 5).Draw(t, "b").([]string)
+		expected := []hash.Pair[int, string]{}
+		for _, x := range a {
+			for _, y := range b {
+				expected = append(expected, hash.Pair[int, string]{A: x, B: y})
+			}
+		}
+		actual := iterable.ToSlice(iterable.Product(iterable.FromSlice(a), iterable.FromSlice(b)))
+		require.Equal(t, expected, append([]hash.Pair[int, string]{}, actual...))
+	})
+}
+
+// positions returns the slice [0, 1, ..., n-1]
+func positions(n int) []int {
+	return iterable.ToSlice(iterable.Range(0, n))
+}
+
+func lexLess(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+func TestPermutations(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		n := rapid.IntRange(0, 6).Draw(t, "n").(int)
+		perms := iterable.ToSlice(iterable.Permutations(iterable.FromSlice(positions(n))))
+		factorial := 1
+		for i := 2; i <= n; i++ {
+			factorial *= i
+		}
+		require.Len(t, perms, factorial)
+		for i, p := range perms {
+			sorted := append([]int{}, p...)
+			sort.Ints(sorted)
+			require.Equal(t, positions(n), sorted, "%v is a permutation", p)
+			if i > 0 {
+				require.True(t, lexLess(perms[i-1], p), "%v < %v", perms[i-1], p)
+			}
+		}
+	})
+}
+
+func TestCombinations(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		n := rapid.IntRange(0, 8).Draw(t, "n").(int)
+		k := rapid.IntRange(-1, 9).Draw(t, "k").(int)
+		combs := iterable.ToSlice(iterable.Combinations(iterable.FromSlice(positions(n)), k))
+		require.Len(t, combs, binomial(n, k))
+		for i, c := range combs {
+			require.Len(t, c, k)
+			for j := 1; j < len(c); j++ {
+				require.Less(t, c[j-1], c[j])
+			}
+			if i > 0 {
+				require.True(t, lexLess(combs[i-1], c), "%v < %v", combs[i-1], c)
+			}
+		}
+	})
+}
+
+func binomial(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	res := 1
+	for i := 1; i <= k; i++ {
+		res = res * (n - k + i) / i
+	}
+	return res
+}
+
+func TestPowerSet(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		n := rapid.IntRange(0, 8).Draw(t, "n").(int)
+		subsets := iterable.ToSlice(iterable.PowerSet(iterable.FromSlice(positions(n))))
+		require.Len(t, subsets, 1<<n)
+		seen := make(map[string]bool)
+		for i, s := range subsets {
+			key := fmt.Sprint(s)
+			require.False(t, seen[key], "duplicate subset %v", s)
+			seen[key] = true
+			if i > 0 {
+				require.LessOrEqual(t, len(subsets[i-1]), len(s))
+			}
+		}
+	})
+}
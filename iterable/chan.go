@@ -0,0 +1,243 @@
+package iterable
+
+import (
+	"context"
+	"sync"
+
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// FromChan returns an iterable that receives elements from the given channel until it is closed.
+// All iterators share the channel, so each element is only returned by one of them.
+func FromChan[T any](ch <-chan T) Iterable[T] {
+	return IterableFun[T](func() Iterator[T] {
+		return Fun[T](func() (T, bool) {
+			x, ok := <-ch
+			return x, ok
+		})
+	})
+}
+
+// ToChan starts a goroutine that sends the elements of the iterable to the returned channel.
+// The channel is closed after the last element or when the context is cancelled.
+// Cancel the context to stop the goroutine if the channel is not read until the end.
+func ToChan[T any](ctx context.Context, it Iterable[T], buffer int) <-chan T {
+	ch := make(chan T, buffer)
+	go func() {
+		defer close(ch)
+		iter := it.Iterator()
+		for {
+			x, ok := iter.Next()
+			if !ok {
+				return
+			}
+			select {
+			case ch <- x:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// Pipe runs a transformation stage concurrently with the consumer of the result.
+// Each call to Iterator starts a goroutine that iterates the result of stage(it)
+// and buffers up to buffer elements.
+// The iteration ends early when the context is cancelled.
+// Cancel the context to stop the goroutine if the iterator is not consumed until the end.
+func Pipe[A, B any](ctx context.Context, it Iterable[A], buffer int, stage func(Iterable[A]) Iterable[B]) Iterable[B] {
+	return IterableFun[B](func() Iterator[B] {
+		return chanIterator(ctx, ToChan(ctx, stage(it), buffer))
+	})
+}
+
+// chanIterator receives elements from a channel, but stops when the context is cancelled
+func chanIterator[T any](ctx context.Context, ch <-chan T) Iterator[T] {
+	return Fun[T](func() (T, bool) {
+		if ctx.Err() != nil {
+			return zero.Value[T](), false
+		}
+		select {
+		case x, ok := <-ch:
+			return x, ok
+		case <-ctx.Done():
+			return zero.Value[T](), false
+		}
+	})
+}
+
+// Merge combines several iterables into one by iterating them concurrently.
+// Each call to Iterator starts one goroutine per iterable.
+// The elements of each iterable appear in order, but elements of different iterables are interleaved arbitrarily.
+// The iteration ends early when the context is cancelled.
+// Cancel the context to stop the goroutines if the iterator is not consumed until the end.
+func Merge[T any](ctx context.Context, its ...Iterable[T]) Iterable[T] {
+	return IterableFun[T](func() Iterator[T] {
+		ch := make(chan T)
+		var wg sync.WaitGroup
+		wg.Add(len(its))
+		for _, it := range its {
+			go func(it Iterable[T]) {
+				defer wg.Done()
+				iter := it.Iterator()
+				for {
+					x, ok := iter.Next()
+					if !ok {
+						return
+					}
+					select {
+					case ch <- x:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}(it)
+		}
+		go func() {
+			wg.Wait()
+			close(ch)
+		}()
+		return chanIterator(ctx, ch)
+	})
+}
+
+// result of applying a function in a worker goroutine
+type result[T any] struct {
+	value      T
+	panicked   bool
+	panicValue any
+}
+
+func tryApply[A, B any](f func(A) B, a A) (res result[B]) {
+	defer func() {
+		if r := recover(); r != nil {
+			res = result[B]{panicked: true, panicValue: r}
+		}
+	}()
+	return result[B]{value: f(a)}
+}
+
+func (r result[T]) get() T {
+	if r.panicked {
+		panic(r.panicValue)
+	}
+	return r.value
+}
+
+// ParallelMap applies f to the elements of the iterable using the given number of worker goroutines.
+// The results are returned in the order of the input elements.
+// At most about 2*workers elements are processed ahead of the consumer.
+// If f panics, the panic is re-raised in the goroutine consuming the result.
+// The iteration ends early when the context is cancelled.
+// Cancel the context to stop the goroutines if the iterator is not consumed until the end.
+func ParallelMap[A, B any](ctx context.Context, it Iterable[A], workers int, f func(A) B) Iterable[B] {
+	if workers < 1 {
+		workers = 1
+	}
+	type job struct {
+		input  A
+		output chan result[B]
+	}
+	return IterableFun[B](func() Iterator[B] {
+		jobs := make(chan job)
+		// futures for the results in input order
+		pending := make(chan chan result[B], workers)
+		go func() {
+			defer close(jobs)
+			defer close(pending)
+			iter := it.Iterator()
+			for {
+				x, ok := iter.Next()
+				if !ok {
+					return
+				}
+				j := job{x, make(chan result[B], 1)}
+				select {
+				case pending <- j.output:
+				case <-ctx.Done():
+					return
+				}
+				select {
+				case jobs <- j:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+		for i := 0; i < workers; i++ {
+			go func() {
+				for j := range jobs {
+					// the output channel is buffered, so this does not block
+					j.output <- tryApply(f, j.input)
+				}
+			}()
+		}
+		return Fun[B](func() (B, bool) {
+			if ctx.Err() != nil {
+				return zero.Value[B](), false
+			}
+			select {
+			case out, ok := <-pending:
+				if !ok {
+					return zero.Value[B](), false
+				}
+				select {
+				case r := <-out:
+					return r.get(), true
+				case <-ctx.Done():
+					return zero.Value[B](), false
+				}
+			case <-ctx.Done():
+				return zero.Value[B](), false
+			}
+		})
+	})
+}
+
+// ParallelMapUnordered applies f to the elements of the iterable using the given number of worker goroutines.
+// The results are returned as soon as they are available, so the order can differ from the input order.
+// If f panics, the panic is re-raised in the goroutine consuming the result.
+// The iteration ends early when the context is cancelled.
+// Cancel the context to stop the goroutines if the iterator is not consumed until the end.
+func ParallelMapUnordered[A, B any](ctx context.Context, it Iterable[A], workers int, f func(A) B) Iterable[B] {
+	if workers < 1 {
+		workers = 1
+	}
+	return IterableFun[B](func() Iterator[B] {
+		inputs := ToChan(ctx, it, 0)
+		results := make(chan result[B], workers)
+		var wg sync.WaitGroup
+		wg.Add(workers)
+		for i := 0; i < workers; i++ {
+			go func() {
+				defer wg.Done()
+				for x := range inputs {
+					select {
+					case results <- tryApply(f, x):
+					case <-ctx.Done():
+						return
+					}
+				}
+			}()
+		}
+		go func() {
+			wg.Wait()
+			close(results)
+		}()
+		return Fun[B](func() (B, bool) {
+			if ctx.Err() != nil {
+				return zero.Value[B](), false
+			}
+			select {
+			case r, ok := <-results:
+				if !ok {
+					return zero.Value[B](), false
+				}
+				return r.get(), true
+			case <-ctx.Done():
+				return zero.Value[B](), false
+			}
+		})
+	})
+}
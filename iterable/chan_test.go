@@ -0,0 +1,168 @@
+package iterable_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleFromChan() {
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 1; i <= 3; i++ {
+			ch <- i
+		}
+	}()
+	fmt.Printf("%v\n", iterable.ToSlice(iterable.FromChan(ch)))
+	// output:
+	// [1 2 3]
+}
+
+func ExampleParallelMap() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	squares := iterable.ParallelMap(ctx, iterable.Range(1, 6), 3, func(x int) int {
+		return x * x
+	})
+	fmt.Printf("%v\n", iterable.ToSlice(squares))
+	// output:
+	// [1 4 9 16 25]
+}
+
+func ExamplePipe() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// the filter runs on a separate goroutine
+	evens := iterable.Pipe(ctx, iterable.Range(0, 10), 4, func(it iterable.Iterable[int]) iterable.Iterable[int] {
+		return iterable.Filter(it, func(x int) bool { return x%2 == 0 })
+	})
+	fmt.Printf("%v\n", iterable.ToSlice(evens))
+	// output:
+	// [0 2 4 6 8]
+}
+
+// waitForGoroutines waits until the number of goroutines is at most n
+func waitForGoroutines(t *testing.T, n int) {
+	for i := 0; i < 100; i++ {
+		if runtime.NumGoroutine() <= n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("goroutines not cleaned up: %d running, expected at most %d", runtime.NumGoroutine(), n)
+}
+
+func TestToChan(t *testing.T) {
+	ch := iterable.ToChan(context.Background(), iterable.Range(0, 5), 2)
+	var res []int
+	for x := range ch {
+		res = append(res, x)
+	}
+	require.Equal(t, []int{0, 1, 2, 3, 4}, res)
+}
+
+func TestMergeAll(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	merged := iterable.Merge(ctx, iterable.Range(0, 100), iterable.Range(100, 200), iterable.Range(200, 300))
+	res := iterable.ToSlice(merged)
+	sort.Ints(res)
+	require.Equal(t, iterable.ToSlice(iterable.Range(0, 300)), res)
+	waitForGoroutines(t, before)
+}
+
+func TestParallelMapOrdered(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	res := iterable.ParallelMap(ctx, iterable.Range(0, 200), 8, func(x int) int {
+		// finish in a different order than started
+		time.Sleep(time.Duration(x%7) * 100 * time.Microsecond)
+		return 2 * x
+	})
+	require.Equal(t, iterable.ToSlice(iterable.RangeStep(0, 400, 2)), iterable.ToSlice(res))
+}
+
+func TestParallelMapUnordered(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	res := iterable.ToSlice(iterable.ParallelMapUnordered(ctx, iterable.Range(0, 200), 8, func(x int) int {
+		return 2 * x
+	}))
+	sort.Ints(res)
+	require.Equal(t, iterable.ToSlice(iterable.RangeStep(0, 400, 2)), res)
+}
+
+func TestParallelMapBoundedConcurrency(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var running, maxRunning int32
+	f := func(x int) int {
+		r := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&maxRunning)
+			if r <= m || atomic.CompareAndSwapInt32(&maxRunning, m, r) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return x
+	}
+	iterable.ToSlice(iterable.ParallelMap(ctx, iterable.Range(0, 30), 3, f))
+	require.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(3))
+	atomic.StoreInt32(&maxRunning, 0)
+	iterable.ToSlice(iterable.ParallelMapUnordered(ctx, iterable.Range(0, 30), 3, f))
+	require.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(3))
+}
+
+func TestParallelMapPanic(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	res := iterable.ParallelMap(ctx, iterable.Range(0, 10), 2, func(x int) int {
+		if x == 3 {
+			panic("boom")
+		}
+		return x
+	})
+	it := res.Iterator()
+	for i := 0; i < 3; i++ {
+		x, ok := it.Next()
+		require.True(t, ok)
+		require.Equal(t, i, x)
+	}
+	require.PanicsWithValue(t, "boom", func() { it.Next() })
+}
+
+func TestCancellationCleansUp(t *testing.T) {
+	before := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	infinite := iterable.Generate(0, func(x int) int { return x + 1 })
+	identity := func(x int) int { return x }
+	its := []iterable.Iterable[int]{
+		iterable.FromChan(iterable.ToChan(ctx, infinite, 0)),
+		iterable.Pipe(ctx, infinite, 5, func(it iterable.Iterable[int]) iterable.Iterable[int] { return it }),
+		iterable.Merge(ctx, infinite, infinite),
+		iterable.ParallelMap(ctx, infinite, 4, identity),
+		iterable.ParallelMapUnordered(ctx, infinite, 4, identity),
+	}
+	for _, it := range its {
+		// consume only a few elements of each infinite iterable
+		require.Equal(t, 3, len(iterable.ToSlice(iterable.Take(3, it))))
+	}
+	cancel()
+	waitForGoroutines(t, before)
+	for _, it := range its[1:] {
+		// after cancellation, new iterators stop immediately
+		require.Equal(t, 0, len(iterable.ToSlice(it)))
+	}
+	waitForGoroutines(t, before)
+}
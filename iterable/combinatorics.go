@@ -0,0 +1,127 @@
+package iterable
+
+import (
+	"github.com/peterzeller/go-fun/hash"
+)
+
+// Product returns the cartesian product of two iterables.
+// The second iterable is iterated once for each element of the first one.
+func Product[A, B any](a Iterable[A], b Iterable[B]) Iterable[hash.Pair[A, B]] {
+	return FlatMap(a, func(x A) Iterable[hash.Pair[A, B]] {
+		return Map(b, func(y B) hash.Pair[A, B] {
+			return hash.Pair[A, B]{A: x, B: y}
+		})
+	})
+}
+
+// Permutations returns all orderings of the elements of the iterable.
+// Permutations are produced in lexicographic order of the element positions, starting with the original order.
+// Elements are compared by position, so an iterable with duplicates yields duplicate permutations.
+// Each permutation is a fresh slice.
+func Permutations[T any](i Iterable[T]) Iterable[[]T] {
+	return IterableFun[[]T](func() Iterator[[]T] {
+		elems := ToSlice(i)
+		indexes := make([]int, len(elems))
+		for j := range indexes {
+			indexes[j] = j
+		}
+		first := true
+		return Fun[[]T](func() ([]T, bool) {
+			if !first && !nextPermutation(indexes) {
+				return nil, false
+			}
+			first = false
+			return pick(elems, indexes), true
+		})
+	})
+}
+
+// nextPermutation updates the indexes to the lexicographically next permutation.
+// Returns false if it was the last permutation.
+func nextPermutation(indexes []int) bool {
+	i := len(indexes) - 2
+	for i >= 0 && indexes[i] >= indexes[i+1] {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	j := len(indexes) - 1
+	for indexes[j] <= indexes[i] {
+		j--
+	}
+	indexes[i], indexes[j] = indexes[j], indexes[i]
+	for l, r := i+1, len(indexes)-1; l < r; l, r = l+1, r-1 {
+		indexes[l], indexes[r] = indexes[r], indexes[l]
+	}
+	return true
+}
+
+// Combinations returns all subsequences of length k of the iterable.
+// Combinations are produced in lexicographic order of the element positions.
+// Each combination is a fresh slice.
+func Combinations[T any](i Iterable[T], k int) Iterable[[]T] {
+	return IterableFun[[]T](func() Iterator[[]T] {
+		elems := ToSlice(i)
+		return combinations(elems, k)
+	})
+}
+
+func combinations[T any](elems []T, k int) Iterator[[]T] {
+	if k < 0 || k > len(elems) {
+		return emptyIterator[[]T]{}
+	}
+	indexes := make([]int, k)
+	for j := range indexes {
+		indexes[j] = j
+	}
+	first := true
+	return Fun[[]T](func() ([]T, bool) {
+		if !first && !nextCombination(indexes, len(elems)) {
+			return nil, false
+		}
+		first = false
+		return pick(elems, indexes), true
+	})
+}
+
+// nextCombination updates the increasing indexes to the next combination of indexes below n.
+// Returns false if it was the last combination.
+func nextCombination(indexes []int, n int) bool {
+	k := len(indexes)
+	i := k - 1
+	for i >= 0 && indexes[i] == n-k+i {
+		i--
+	}
+	if i < 0 {
+		return false
+	}
+	indexes[i]++
+	for j := i + 1; j < k; j++ {
+		indexes[j] = indexes[j-1] + 1
+	}
+	return true
+}
+
+// PowerSet returns all subsequences of the iterable, ordered by length.
+// Subsequences of the same length are ordered as in Combinations.
+// Each subsequence is a fresh slice.
+func PowerSet[T any](i Iterable[T]) Iterable[[]T] {
+	return IterableFun[[]T](func() Iterator[[]T] {
+		elems := ToSlice(i)
+		return FlatMap(RangeI(0, len(elems)), func(k int) Iterable[[]T] {
+			return IterableFun[[]T](func() Iterator[[]T] {
+				return combinations(elems, k)
+			})
+		}).Iterator()
+	})
+}
+
+// pick returns a new slice with the elements at the given indexes
+func pick[T any](elems []T, indexes []int) []T {
+	res := make([]T, len(indexes))
+	for j, idx := range indexes {
+		res[j] = elems[idx]
+	}
+	return res
+}
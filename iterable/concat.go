@@ -1,8 +1,33 @@
 package iterable
 
-import "github.com/peterzeller/go-fun/zero"
+import (
+	"fmt"
 
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// Concat returns the elements of all given iterables, one after the other.
+// The result is Sized or RandomAccess if all given iterables are.
 func Concat[T any](iterables ...Iterable[T]) Iterable[T] {
+	allSized := true
+	ras := make([]RandomAccess[T], 0, len(iterables))
+	for _, it := range iterables {
+		if ra, ok := it.(RandomAccess[T]); ok {
+			ras = append(ras, ra)
+		} else if _, ok := KnownLength(it); !ok {
+			allSized = false
+		}
+	}
+	if len(ras) == len(iterables) {
+		return randomAccessConcat[T]{ras}
+	}
+	if allSized {
+		return sizedConcat[T]{iterables}
+	}
+	return concat(iterables)
+}
+
+func concat[T any](iterables []Iterable[T]) Iterable[T] {
 	return IterableFun[T](func() Iterator[T] {
 		pos := 0
 		var current Iterator[T]
@@ -25,6 +50,55 @@ func Concat[T any](iterables ...Iterable[T]) Iterable[T] {
 	})
 }
 
+// sizedConcat is the concatenation of iterables with known lengths
+type sizedConcat[T any] struct {
+	iterables []Iterable[T]
+}
+
+func (c sizedConcat[T]) Length() int {
+	res := 0
+	for _, it := range c.iterables {
+		res += Length(it)
+	}
+	return res
+}
+
+func (c sizedConcat[T]) Iterator() Iterator[T] {
+	return concat(c.iterables).Iterator()
+}
+
+// randomAccessConcat is the concatenation of RandomAccess iterables
+type randomAccessConcat[T any] struct {
+	iterables []RandomAccess[T]
+}
+
+func (c randomAccessConcat[T]) Length() int {
+	res := 0
+	for _, it := range c.iterables {
+		res += it.Length()
+	}
+	return res
+}
+
+func (c randomAccessConcat[T]) At(i int) T {
+	for _, it := range c.iterables {
+		l := it.Length()
+		if i < l {
+			return it.At(i)
+		}
+		i -= l
+	}
+	panic(fmt.Errorf("index out of range"))
+}
+
+func (c randomAccessConcat[T]) Iterator() Iterator[T] {
+	iterators := make([]Iterator[T], len(c.iterables))
+	for i, it := range c.iterables {
+		iterators[i] = it.Iterator()
+	}
+	return ConcatIterators(iterators...)
+}
+
 func ConcatIterators[T any](iterators ...Iterator[T]) Iterator[T] {
 	pos := 0
 	return Fun[T](func() (T, bool) {
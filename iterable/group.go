@@ -0,0 +1,62 @@
+package iterable
+
+import (
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// GroupAdjacent groups runs of adjacent elements that are equal according to eq.
+// Each element is compared to the first element of its group.
+func GroupAdjacent[T any](i Iterable[T], eq equality.Equality[T]) Iterable[[]T] {
+	return IterableFun[[]T](func() Iterator[[]T] {
+		it := i.Iterator()
+		next, hasNext := it.Next()
+		return Fun[[]T](func() ([]T, bool) {
+			if !hasNext {
+				return nil, false
+			}
+			group := []T{next}
+			for {
+				next, hasNext = it.Next()
+				if !hasNext || !eq.Equal(group[0], next) {
+					return group, true
+				}
+				group = append(group, next)
+			}
+		})
+	})
+}
+
+// Distinct removes duplicate elements from the iterable, keeping the first occurrence.
+// Elements are compared using the given EqHash instance.
+func Distinct[T any](i Iterable[T], eq hash.EqHash[T]) Iterable[T] {
+	return IterableFun[T](func() Iterator[T] {
+		it := i.Iterator()
+		// seen elements, grouped by hash
+		seen := make(map[int64][]T)
+		return Fun[T](func() (T, bool) {
+		outer:
+			for {
+				x, ok := it.Next()
+				if !ok {
+					return zero.Value[T](), false
+				}
+				h := eq.Hash(x)
+				for _, y := range seen[h] {
+					if eq.Equal(x, y) {
+						continue outer
+					}
+				}
+				seen[h] = append(seen[h], x)
+				return x, true
+			}
+		})
+	})
+}
+
+// Partition splits the iterable into the elements that satisfy the predicate and the elements that do not.
+// Both results are lazy and iterate over the base iterable independently.
+func Partition[T any](i Iterable[T], pred func(T) bool) (Iterable[T], Iterable[T]) {
+	return Filter(i, pred), Filter(i, func(x T) bool { return !pred(x) })
+}
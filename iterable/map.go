@@ -1,11 +1,19 @@
 package iterable
 
 import (
-	"github.com/peterzeller/go-fun/slice"
+	"github.com/peterzeller/go-fun/internal/fingertree"
 	"github.com/peterzeller/go-fun/zero"
 )
 
+// Map applies a function to each element of the iterable.
+// The result is Sized or RandomAccess if the base iterable is.
 func Map[A, B any](base Iterable[A], f func(A) B) Iterable[B] {
+	if ra, ok := base.(RandomAccess[A]); ok {
+		return randomAccessMap[A, B]{ra, f}
+	}
+	if _, ok := KnownLength(base); ok {
+		return sizedMapIterable[A, B]{mapIterable[A, B]{base, f}}
+	}
 	return &mapIterable[A, B]{base, f}
 }
 
@@ -18,11 +26,34 @@ type mapIterable[A, B any] struct {
 	f    func(A) B
 }
 
+// sizedMapIterable is a mapIterable where the base has a known length
+type sizedMapIterable[A, B any] struct {
+	mapIterable[A, B]
+}
+
 // Length of a mapIterable is the same as the length of the base
-func (i mapIterable[A, B]) Length() int {
+func (i sizedMapIterable[A, B]) Length() int {
 	return Length(i.base)
 }
 
+// randomAccessMap applies the function on every access
+type randomAccessMap[A, B any] struct {
+	base RandomAccess[A]
+	f    func(A) B
+}
+
+func (i randomAccessMap[A, B]) Length() int {
+	return i.base.Length()
+}
+
+func (i randomAccessMap[A, B]) At(index int) B {
+	return i.f(i.base.At(index))
+}
+
+func (i randomAccessMap[A, B]) Iterator() Iterator[B] {
+	return &mapIterator[A, B]{i.base.Iterator(), i.f}
+}
+
 type mapIterator[A, B any] struct {
 	base Iterator[A]
 	f    func(A) B
@@ -63,41 +94,45 @@ func FlatMap[A, B any](base Iterable[A], f func(A) Iterable[B]) Iterable[B] {
 	})
 }
 
+// Flatten concatenates the inner iterables.
+func Flatten[T any](i Iterable[Iterable[T]]) Iterable[T] {
+	return FlatMap(i, func(x Iterable[T]) Iterable[T] {
+		return x
+	})
+}
+
 func FlatMapBreadthFirst[A, B any](base Iterable[A], f func(A) Iterable[B]) Iterable[B] {
 	return IterableFun[B](func() Iterator[B] {
 		it := base.Iterator()
 		firstPass := true
-		var iterators []Iterator[B]
-		pos := 0
+		// queue of iterators, that are visited in a round-robin fashion
+		var iterators fingertree.Tree[Iterator[B]]
 		return Fun[B](func() (B, bool) {
 			for {
-				if !firstPass && len(iterators) == 0 {
-					return zero.Value[B](), false
-				}
-				if pos >= len(iterators) {
-					if firstPass {
-						// get next element from base iterator
-						i, ok := it.Next()
-						if ok {
-							iterators = append(iterators, f(i).Iterator())
-						} else {
-							// no more element in base iterator
-							firstPass = false
-							pos = 0
-							continue
-						}
+				var current Iterator[B]
+				if firstPass {
+					// get next element from base iterator
+					i, ok := it.Next()
+					if ok {
+						current = f(i).Iterator()
 					} else {
-						pos = 0
+						// no more element in base iterator
+						firstPass = false
 						continue
 					}
+				} else {
+					var ok bool
+					current, iterators, ok = iterators.PopFront()
+					if !ok {
+						return zero.Value[B](), false
+					}
 				}
-				r, ok := iterators[pos].Next()
+				r, ok := current.Next()
 				if ok {
-					pos++
+					// continue with the iterator in the next round
+					iterators = iterators.PushBack(current)
 					return r, true
 				}
-				// remove iterator from iterators list and try with next position
-				iterators = slice.RemoveAt(iterators, pos)
 			}
 		})
 	})
@@ -18,8 +18,12 @@ func RangeI[N Number](start N, end N) Iterable[N] {
 	return RangeIStep(start, end, 1)
 }
 
-// Range of numbers from start (inclusive) to end (exclusive), increasing by step between elements
+// Range of numbers from start (inclusive) to end (exclusive), increasing by step between elements.
+// For integer types with a non-zero step, the result supports random access.
 func RangeStep[N Number](start N, end N, step N) Iterable[N] {
+	if r, ok := newIntRange(start, end, step, false); ok {
+		return r
+	}
 	return IterableFun[N](func() Iterator[N] {
 		n := start
 		return Fun[N](func() (N, bool) {
@@ -33,8 +37,12 @@ func RangeStep[N Number](start N, end N, step N) Iterable[N] {
 	})
 }
 
-// Range of numbers from start (inclusive) to end (inclusive), increasing by step between elements
+// Range of numbers from start (inclusive) to end (inclusive), increasing by step between elements.
+// For integer types with a non-zero step, the result supports random access.
 func RangeIStep[N Number](start N, end N, step N) Iterable[N] {
+	if r, ok := newIntRange(start, end, step, true); ok {
+		return r
+	}
 	return IterableFun[N](func() Iterator[N] {
 		n := start
 		return Fun[N](func() (N, bool) {
@@ -47,3 +55,57 @@ func RangeIStep[N Number](start N, end N, step N) Iterable[N] {
 		})
 	})
 }
+
+// intRange is a range over an integer type with a non-zero step
+type intRange[N Number] struct {
+	start  N
+	step   N
+	length int
+}
+
+// newIntRange creates a RandomAccess range.
+// Returns false if N is not an integer type, the step is 0, or the length does not fit into an int.
+func newIntRange[N Number](start, end, step N, inclusive bool) (intRange[N], bool) {
+	one, two := N(1), N(2)
+	if one/two != 0 || step == 0 {
+		return intRange[N]{}, false
+	}
+	// differences are computed modulo 2^64, which is exact for all integer types
+	var diff, stepSize uint64
+	if step > 0 {
+		if end < start || !inclusive && end == start {
+			return intRange[N]{start, step, 0}, true
+		}
+		diff = uint64(end) - uint64(start)
+		stepSize = uint64(step)
+	} else {
+		if end > start || !inclusive && end == start {
+			return intRange[N]{start, step, 0}, true
+		}
+		diff = uint64(start) - uint64(end)
+		stepSize = -uint64(step)
+	}
+	count := diff / stepSize
+	if inclusive || diff%stepSize != 0 {
+		count++
+	}
+	if count == 0 || count > uint64(maxInt) {
+		// overflow
+		return intRange[N]{}, false
+	}
+	return intRange[N]{start, step, int(count)}, true
+}
+
+const maxInt = int(^uint(0) >> 1)
+
+func (r intRange[N]) Length() int {
+	return r.length
+}
+
+func (r intRange[N]) At(i int) N {
+	return r.start + N(i)*r.step
+}
+
+func (r intRange[N]) Iterator() Iterator[N] {
+	return iterateRandomAccess[N](r)
+}
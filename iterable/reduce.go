@@ -1,21 +1,26 @@
 package iterable
 
-type hasLength interface {
-	Length() int
-}
-
 type hasSize interface {
 	Size() int
 }
 
-// Length calculates the number of elements in an iterable.
-// This operation takes linear time, unless the iterable implements a Length or Size method
-func Length[T any](i Iterable[T]) (size int) {
-	if h, ok := i.(hasLength); ok {
-		return h.Length()
+// KnownLength returns the number of elements in the iterable if it can be determined without iterating,
+// that is if the iterable implements Sized or has a Size method.
+func KnownLength[T any](i Iterable[T]) (int, bool) {
+	if h, ok := i.(Sized); ok {
+		return h.Length(), true
 	}
 	if h, ok := i.(hasSize); ok {
-		return h.Size()
+		return h.Size(), true
+	}
+	return 0, false
+}
+
+// Length calculates the number of elements in an iterable.
+// This operation takes linear time, unless the iterable implements Sized or has a Size method
+func Length[T any](i Iterable[T]) (size int) {
+	if n, ok := KnownLength(i); ok {
+		return n
 	}
 	for it := Start(i); it.HasNext(); it.Next() {
 		size++
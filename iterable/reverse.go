@@ -0,0 +1,57 @@
+package iterable
+
+import (
+	"sort"
+
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// Reverse returns the elements of the iterable in reverse order.
+// If the iterable supports random access, the result does as well and no elements are copied.
+// Otherwise, all elements are collected into a slice whenever an iterator is created.
+func Reverse[T any](i Iterable[T]) Iterable[T] {
+	if ra, ok := i.(RandomAccess[T]); ok {
+		return reversed[T]{ra}
+	}
+	return IterableFun[T](func() Iterator[T] {
+		s := ToSlice(i)
+		pos := len(s)
+		return Fun[T](func() (T, bool) {
+			if pos <= 0 {
+				return zero.Value[T](), false
+			}
+			pos--
+			return s[pos], true
+		})
+	})
+}
+
+// reversed is a reversed view on a RandomAccess iterable
+type reversed[T any] struct {
+	base RandomAccess[T]
+}
+
+func (r reversed[T]) Length() int {
+	return r.base.Length()
+}
+
+func (r reversed[T]) At(i int) T {
+	return r.base.At(r.base.Length() - 1 - i)
+}
+
+func (r reversed[T]) Iterator() Iterator[T] {
+	return iterateRandomAccess[T](r)
+}
+
+// SortBy returns the elements of the iterable sorted by the given less function.
+// The sort is stable, so equal elements keep their relative order.
+// Sorting is done lazily, whenever an iterator is created.
+func SortBy[T any](i Iterable[T], less func(a, b T) bool) Iterable[T] {
+	return IterableFun[T](func() Iterator[T] {
+		s := ToSlice(i)
+		sort.SliceStable(s, func(x, y int) bool {
+			return less(s[x], s[y])
+		})
+		return FromSlice(s).Iterator()
+	})
+}
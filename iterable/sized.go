@@ -0,0 +1,34 @@
+package iterable
+
+// Sized is implemented by iterables that know their number of elements without iterating.
+type Sized interface {
+	Length() int
+}
+
+// RandomAccess is implemented by iterables that support efficient access to elements by their index.
+type RandomAccess[T any] interface {
+	Iterable[T]
+	Sized
+	// At returns the element at the given index, where 0 <= i < Length().
+	At(i int) T
+}
+
+// randomAccessIterator iterates over a RandomAccess iterable using its At method
+type randomAccessIterator[T any] struct {
+	ra  RandomAccess[T]
+	pos int
+	len int
+}
+
+func (it *randomAccessIterator[T]) Next() (res T, ok bool) {
+	if it.pos >= it.len {
+		return res, false
+	}
+	res = it.ra.At(it.pos)
+	it.pos++
+	return res, true
+}
+
+func iterateRandomAccess[T any](ra RandomAccess[T]) Iterator[T] {
+	return &randomAccessIterator[T]{ra: ra, len: ra.Length()}
+}
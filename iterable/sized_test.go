@@ -0,0 +1,89 @@
+package iterable_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+func ExampleRandomAccess() {
+	squares := iterable.Map(iterable.Range(0, 100), func(x int) int { return x * x })
+	ra := squares.(iterable.RandomAccess[int])
+	fmt.Printf("length = %d, squares[7] = %d\n", ra.Length(), ra.At(7))
+	// output: length = 100, squares[7] = 49
+}
+
+// checkRandomAccess checks that Length and At are consistent with the Iterator
+func checkRandomAccess[T any](t require.TestingT, it iterable.Iterable[T]) {
+	elems := iterable.ToSlice(it)
+	ra, ok := it.(iterable.RandomAccess[T])
+	require.True(t, ok, "expected RandomAccess but got %T", it)
+	require.Equal(t, len(elems), ra.Length())
+	for i, e := range elems {
+		require.Equal(t, e, ra.At(i), "element at %d", i)
+	}
+}
+
+func TestRangeRandomAccess(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		start := rapid.IntRange(-20, 20).Draw(t, "start").(int)
+		end := rapid.IntRange(-20, 20).Draw(t, "end").(int)
+		step := rapid.IntRange(-5, 5).Filter(func(x int) bool { return x != 0 }).Draw(t, "step").(int)
+		checkRandomAccess(t, iterable.RangeStep(start, end, step))
+		checkRandomAccess(t, iterable.RangeIStep(start, end, step))
+	})
+}
+
+func TestRangeSmallTypes(t *testing.T) {
+	checkRandomAccess(t, iterable.RangeStep[uint8](250, 3, 255))
+	require.Equal(t, 256, iterable.Length(iterable.RangeI[uint8](0, 255)))
+	require.Equal(t, 256, iterable.Length(iterable.RangeI[int8](-128, 127)))
+	require.Equal(t, []int8{127, 0, -127}, iterable.ToSlice(iterable.RangeIStep[int8](127, -128, -127)))
+	l, ok := iterable.KnownLength(iterable.RangeI(math.MinInt64, math.MaxInt64))
+	require.False(t, ok, "length %d does not fit into an int", l)
+}
+
+func TestRangeFloat(t *testing.T) {
+	r := iterable.RangeStep(0, 1, 0.25)
+	_, ok := r.(iterable.Sized)
+	require.False(t, ok)
+	require.Equal(t, []float64{0, 0.25, 0.5, 0.75}, iterable.ToSlice(r))
+}
+
+func TestDerivedRandomAccess(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		a := rapid.SliceOf(rapid.Int()).Draw(t, "a").([]int)
+		b := rapid.SliceOf(rapid.Int()).Draw(t, "b").([]int)
+		n := rapid.IntRange(-1, 10).Draw(t, "n").(int)
+		checkRandomAccess(t, iterable.FromSlice(a))
+		checkRandomAccess(t, iterable.Map(iterable.FromSlice(a), func(x int) string { return fmt.Sprint(x) }))
+		checkRandomAccess(t, iterable.Take(n, iterable.FromSlice(a)))
+		checkRandomAccess(t, iterable.Concat(iterable.FromSlice(a), iterable.Take(n, iterable.FromSlice(b)), iterable.FromSlice(a)))
+	})
+}
+
+func TestUnknownLength(t *testing.T) {
+	filtered := iterable.Filter(iterable.Range(0, 10), func(x int) bool { return true })
+	_, ok := iterable.KnownLength(filtered)
+	require.False(t, ok)
+
+	// a channel can only be consumed once, so its length must not be computed by iterating
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	it := iterable.Map(iterable.FromChan(ch), func(x int) int { return x * 10 })
+	_, ok = it.(iterable.Sized)
+	require.False(t, ok)
+	require.Equal(t, []int{10, 20, 30}, iterable.ToSlice(it))
+}
+
+func TestStringBytesRandomAccess(t *testing.T) {
+	checkRandomAccess(t, iterable.FromStringBytes("hello"))
+	checkRandomAccess(t, iterable.FromStringBytes(""))
+}
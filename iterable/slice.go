@@ -8,6 +8,10 @@ func (s sliceIterable[T]) Length() int {
 	return len(s.slice)
 }
 
+func (s sliceIterable[T]) At(i int) T {
+	return s.slice[i]
+}
+
 type sliceIterator[T any] struct {
 	slice []T
 }
@@ -34,7 +38,9 @@ func (s *sliceIterator[T]) Next() (next T, ok bool) {
 }
 
 func ToSlice[T any](i Iterable[T]) []T {
-	res := make([]T, 0, Length(i))
+	// only pre-allocate if the length is known, since some iterables can only be iterated once
+	n, _ := KnownLength(i)
+	res := make([]T, 0, n)
 	it := i.Iterator()
 	for {
 		if n, ok := it.Next(); ok {
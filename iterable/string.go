@@ -8,16 +8,22 @@ func FromString(s string) Iterable[rune] {
 	return FromSlice(runes)
 }
 
+// FromStringBytes returns the bytes of a string.
+// The result supports random access.
 func FromStringBytes(s string) Iterable[byte] {
-	return IterableFun[byte](func() Iterator[byte] {
-		pos := 0
-		return Fun[byte](func() (byte, bool) {
-			if pos >= len(s) {
-				return 0, false
-			}
-			b := s[pos]
-			pos++
-			return b, true
-		})
-	})
+	return stringBytes(s)
+}
+
+type stringBytes string
+
+func (s stringBytes) Length() int {
+	return len(s)
+}
+
+func (s stringBytes) At(i int) byte {
+	return s[i]
+}
+
+func (s stringBytes) Iterator() Iterator[byte] {
+	return iterateRandomAccess[byte](s)
 }
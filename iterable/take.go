@@ -1,22 +1,80 @@
 package iterable
 
-import "github.com/peterzeller/go-fun/zero"
+import (
+	"fmt"
 
-// Take the first n elements from the iterable
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// Take the first n elements from the iterable.
+// The result is Sized or RandomAccess if the base iterable is.
 func Take[T any](n int, i Iterable[T]) Iterable[T] {
-	return IterableFun[T](func() Iterator[T] {
-		count := 0
-		it := i.Iterator()
-		return Fun[T](func() (T, bool) {
-			if count >= n {
-				return zero.Value[T](), false
-			}
-			count++
-			return it.Next()
-		})
+	t := takeIterable[T]{n, i}
+	if ra, ok := i.(RandomAccess[T]); ok {
+		return randomAccessTake[T]{t, ra}
+	}
+	if _, ok := KnownLength(i); ok {
+		return sizedTake[T]{t}
+	}
+	return t
+}
+
+type takeIterable[T any] struct {
+	n    int
+	base Iterable[T]
+}
+
+func (t takeIterable[T]) Iterator() Iterator[T] {
+	count := 0
+	it := t.base.Iterator()
+	return Fun[T](func() (T, bool) {
+		if count >= t.n {
+			return zero.Value[T](), false
+		}
+		count++
+		return it.Next()
 	})
 }
 
+// sizedTake is a takeIterable where the base has a known length
+type sizedTake[T any] struct {
+	takeIterable[T]
+}
+
+func (t sizedTake[T]) Length() int {
+	return takeLength(t.n, Length(t.base))
+}
+
+type randomAccessTake[T any] struct {
+	takeIterable[T]
+	ra RandomAccess[T]
+}
+
+func (t randomAccessTake[T]) Length() int {
+	return takeLength(t.n, t.ra.Length())
+}
+
+func (t randomAccessTake[T]) At(i int) T {
+	if i < 0 || i >= t.Length() {
+		panic(fmt.Errorf("index %d out of range [0, %d)", i, t.Length()))
+	}
+	return t.ra.At(i)
+}
+
+func (t randomAccessTake[T]) Iterator() Iterator[T] {
+	return iterateRandomAccess[T](t)
+}
+
+func takeLength(n, length int) int {
+	if n < 0 {
+		return 0
+	}
+	if n < length {
+		return n
+	}
+	return length
+}
+
 // TakeWhile takes elements from the iterable, while the elements match the condition
 func TakeWhile[T any](cond func(T) bool, i Iterable[T]) Iterable[T] {
 	return IterableFun[T](func() Iterator[T] {
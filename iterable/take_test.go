@@ -13,6 +13,13 @@ func TestTake(t *testing.T) {
 	require.Equal(t, []int{1, 2}, iterable.ToSlice(it))
 }
 
+func TestTakeAtOutOfRange(t *testing.T) {
+	it := iterable.Take(2, iterable.FromSlice([]int{1, 2, 3, 4, 5})).(iterable.RandomAccess[int])
+	require.Equal(t, 2, it.At(1))
+	require.Panics(t, func() { it.At(2) })
+	require.Panics(t, func() { it.At(-1) })
+}
+
 func ExampleTakeWhile() {
 	it := iterable.TakeWhile(func(x int) bool {
 		return x <= 3
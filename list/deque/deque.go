@@ -0,0 +1,142 @@
+package deque
+
+import (
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/internal/fingertree"
+	"github.com/peterzeller/go-fun/iterable"
+)
+
+// Deque is an immutable double-ended queue.
+// The zero value is the empty deque.
+type Deque[T any] struct {
+	tree fingertree.Tree[T]
+}
+
+// New creates a deque with the given elements
+func New[T any](elems ...T) Deque[T] {
+	return Deque[T]{fingertree.New(elems...)}
+}
+
+// FromIterable creates a deque from the elements of the iterable
+func FromIterable[T any](elems iterable.Iterable[T]) Deque[T] {
+	var res Deque[T]
+	for it := elems.Iterator(); ; {
+		x, ok := it.Next()
+		if !ok {
+			return res
+		}
+		res = res.PushBack(x)
+	}
+}
+
+// Length returns the number of elements in the deque
+func (d Deque[T]) Length() int {
+	return d.tree.Size()
+}
+
+// Empty checks whether the deque has no elements
+func (d Deque[T]) Empty() bool {
+	return d.tree.Size() == 0
+}
+
+// PushFront adds an element at the front of the deque
+func (d Deque[T]) PushFront(x T) Deque[T] {
+	return Deque[T]{d.tree.PushFront(x)}
+}
+
+// PushBack adds an element at the back of the deque
+func (d Deque[T]) PushBack(x T) Deque[T] {
+	return Deque[T]{d.tree.PushBack(x)}
+}
+
+// PopFront returns the first element and the remaining deque.
+// Returns false if the deque is empty.
+func (d Deque[T]) PopFront() (T, Deque[T], bool) {
+	x, rest, ok := d.tree.PopFront()
+	return x, Deque[T]{rest}, ok
+}
+
+// PopBack returns the last element and the remaining deque.
+// Returns false if the deque is empty.
+func (d Deque[T]) PopBack() (T, Deque[T], bool) {
+	x, rest, ok := d.tree.PopBack()
+	return x, Deque[T]{rest}, ok
+}
+
+// First returns the first element of the deque.
+// Returns false if the deque is empty.
+func (d Deque[T]) First() (T, bool) {
+	x, _, ok := d.tree.PopFront()
+	return x, ok
+}
+
+// Last returns the last element of the deque.
+// Returns false if the deque is empty.
+func (d Deque[T]) Last() (T, bool) {
+	x, _, ok := d.tree.PopBack()
+	return x, ok
+}
+
+// At returns the element at index i.
+// Panics if the index is out of range.
+func (d Deque[T]) At(i int) T {
+	return d.tree.At(i)
+}
+
+// Concat appends the other deque to this deque
+func (d Deque[T]) Concat(other Deque[T]) Deque[T] {
+	return Deque[T]{d.tree.Concat(other.tree)}
+}
+
+// SplitAt splits the deque into the first i elements and the remaining elements
+func (d Deque[T]) SplitAt(i int) (Deque[T], Deque[T]) {
+	l, r := d.tree.SplitAt(i)
+	return Deque[T]{l}, Deque[T]{r}
+}
+
+// Skip the first n elements of the deque
+func (d Deque[T]) Skip(n int) Deque[T] {
+	_, r := d.SplitAt(n)
+	return r
+}
+
+// Limit the length of the deque and take only the first n elements
+func (d Deque[T]) Limit(n int) Deque[T] {
+	l, _ := d.SplitAt(n)
+	return l
+}
+
+// Iterator for the deque, starting at the front
+func (d Deque[T]) Iterator() iterable.Iterator[T] {
+	return iterable.Fun[T](d.tree.Iterator())
+}
+
+// Equal checks whether this deque is equal to another deque
+func (d Deque[T]) Equal(other Deque[T], eq equality.Equality[T]) bool {
+	if d.Length() != other.Length() {
+		return false
+	}
+	it1 := d.Iterator()
+	it2 := other.Iterator()
+	for {
+		a, ok := it1.Next()
+		if !ok {
+			return true
+		}
+		b, _ := it2.Next()
+		if !eq.Equal(a, b) {
+			return false
+		}
+	}
+}
+
+// Equality returns an equality instance for deques, given an equality instance for the elements.
+func Equality[T any](eq equality.Equality[T]) equality.Equality[Deque[T]] {
+	return equality.Fun[Deque[T]](func(a, b Deque[T]) bool {
+		return a.Equal(b, eq)
+	})
+}
+
+func (d Deque[T]) String() string {
+	return iterable.String[T](d)
+}
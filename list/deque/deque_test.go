@@ -0,0 +1,146 @@
+package deque_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/deque"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+func ExampleDeque_PushBack() {
+	d := deque.New(1, 2, 3)
+	d2 := d.PushBack(4).PushFront(0)
+	fmt.Printf("d = %v\n", d)
+	fmt.Printf("d2 = %v\n", d2)
+	// output:
+	// d = [1, 2, 3]
+	// d2 = [0, 1, 2, 3, 4]
+}
+
+func ExampleDeque_PopFront() {
+	d := deque.New(1, 2, 3)
+	x, rest, _ := d.PopFront()
+	y, rest, _ := rest.PopBack()
+	fmt.Printf("x = %v, y = %v, rest = %v\n", x, y, rest)
+	// output:
+	// x = 1, y = 3, rest = [2]
+}
+
+func ExampleDeque_SplitAt() {
+	d := deque.FromIterable(iterable.Range(0, 10))
+	l, r := d.SplitAt(4)
+	fmt.Printf("l = %v\nr = %v\n", l, r)
+	fmt.Printf("r.At(2) = %v\n", r.At(2))
+	fmt.Printf("concat = %v\n", r.Concat(l))
+	// output:
+	// l = [0, 1, 2, 3]
+	// r = [4, 5, 6, 7, 8, 9]
+	// r.At(2) = 6
+	// concat = [4, 5, 6, 7, 8, 9, 0, 1, 2, 3]
+}
+
+func assertDequeEqual(t *rapid.T, expected []int, d deque.Deque[int]) {
+	require.Equal(t, len(expected), d.Length())
+	require.Equal(t, expected, append([]int{}, iterable.ToSlice[int](d)...))
+	for i, x := range expected {
+		require.Equal(t, x, d.At(i))
+	}
+}
+
+func TestDequeOperations(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		deques := []deque.Deque[int]{deque.New[int]()}
+		models := [][]int{{}}
+		n := rapid.IntRange(1, 100).Draw(t, "n").(int)
+		for i := 0; i < n; i++ {
+			j := rapid.IntRange(0, len(deques)-1).Draw(t, "deque").(int)
+			d := deques[j]
+			m := models[j]
+			var newD deque.Deque[int]
+			newM := []int{}
+			switch rapid.IntRange(0, 6).Draw(t, "cmd").(int) {
+			case 0:
+				x := rapid.IntRange(0, 100).Draw(t, "x").(int)
+				newD = d.PushFront(x)
+				newM = append(append(newM, x), m...)
+			case 1:
+				x := rapid.IntRange(0, 100).Draw(t, "x").(int)
+				newD = d.PushBack(x)
+				newM = append(append(newM, m...), x)
+			case 2:
+				x, rest, ok := d.PopFront()
+				require.Equal(t, len(m) > 0, ok)
+				if ok {
+					require.Equal(t, m[0], x)
+					newM = append(newM, m[1:]...)
+				}
+				newD = rest
+			case 3:
+				x, rest, ok := d.PopBack()
+				require.Equal(t, len(m) > 0, ok)
+				if ok {
+					require.Equal(t, m[len(m)-1], x)
+					newM = append(newM, m[:len(m)-1]...)
+				}
+				newD = rest
+			case 4:
+				k := rapid.IntRange(0, len(deques)-1).Draw(t, "other").(int)
+				newD = d.Concat(deques[k])
+				newM = append(append(newM, m...), models[k]...)
+			case 5:
+				k := rapid.IntRange(-1, len(m)+1).Draw(t, "split").(int)
+				l, r := d.SplitAt(k)
+				if k < 0 {
+					k = 0
+				}
+				if k > len(m) {
+					k = len(m)
+				}
+				assertDequeEqual(t, m[:k], l)
+				newD = r
+				newM = append(newM, m[k:]...)
+			case 6:
+				// many elements to get deeper trees
+				for x := 0; x < 50; x++ {
+					d = d.PushBack(x)
+					m = append(m, x)
+				}
+				newD = d
+				newM = append(newM, m...)
+			}
+			deques = append(deques, newD)
+			models = append(models, newM)
+			assertDequeEqual(t, newM, newD)
+		}
+		// all versions must remain valid
+		for k := range deques {
+			assertDequeEqual(t, models[k], deques[k])
+		}
+	})
+}
+
+func TestDequeLarge(t *testing.T) {
+	var d deque.Deque[int]
+	for i := 0; i < 10000; i++ {
+		d = d.PushBack(i)
+	}
+	for i := 0; i < 10000; i += 37 {
+		require.Equal(t, i, d.At(i))
+		l, r := d.SplitAt(i)
+		require.Equal(t, i, l.Length())
+		require.Equal(t, 10000-i, r.Length())
+		first, _ := r.First()
+		require.Equal(t, i, first)
+		require.True(t, l.Concat(r).Equal(d, equality.Default[int]()))
+	}
+	for i := 0; i < 10000; i++ {
+		var x int
+		x, d, _ = d.PopFront()
+		require.Equal(t, i, x)
+	}
+	require.True(t, d.Empty())
+}
@@ -0,0 +1,7 @@
+/*
+Package deque implements an immutable double-ended queue.
+
+The deque is based on a finger tree, so adding and removing elements at both ends takes amortized constant time,
+while indexing, splitting and concatenation take logarithmic time.
+*/
+package deque
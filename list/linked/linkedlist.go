@@ -151,6 +151,13 @@ func (l *List[T]) Equal(other *List[T], eq equality.Equality[T]) bool {
 	}
 }
 
+// Equality returns an equality instance for lists, given an equality instance for the elements.
+func Equality[T any](eq equality.Equality[T]) equality.Equality[*List[T]] {
+	return equality.Fun[*List[T]](func(a, b *List[T]) bool {
+		return a.Equal(b, eq)
+	})
+}
+
 // PrefixOf checks whether this list is a prefix of another list
 func (l *List[T]) PrefixOf(other *List[T], eq equality.Equality[T]) bool {
 	a := l
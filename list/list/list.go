@@ -13,6 +13,8 @@ type List[T any] struct {
 	slice []T
 }
 
+var _ iterable.RandomAccess[int] = List[int]{}
+
 // At returns the element at the given position
 func (l List[T]) At(i int) T {
 	return l.slice[i]
@@ -44,8 +46,10 @@ func New[T any](elems ...T) List[T] {
 }
 
 // FromIterable creates a new list from an iterable
+// The backing slice is pre-sized if the length of the iterable is known (see iterable.KnownLength).
 func FromIterable[T any](i iterable.Iterable[T]) List[T] {
-	s := make([]T, 0, iterable.Length(i))
+	n, _ := iterable.KnownLength(i)
+	s := make([]T, 0, n)
 	for it := iterable.Start(i); it.HasNext(); it.Next() {
 		s = append(s, it.Current())
 	}
@@ -92,6 +96,13 @@ func (l List[T]) Equal(other List[T], eq equality.Equality[T]) bool {
 	return slice.Equal(l.slice, other.slice, eq)
 }
 
+// Equality returns an equality instance for lists, given an equality instance for the elements.
+func Equality[T any](eq equality.Equality[T]) equality.Equality[List[T]] {
+	return equality.Fun[List[T]](func(a, b List[T]) bool {
+		return a.Equal(b, eq)
+	})
+}
+
 // PrefixOf checks whether this list is a prefix of another list
 func (l List[T]) PrefixOf(other List[T], eq equality.Equality[T]) bool {
 	return slice.PrefixOf(l.slice, other.slice, eq)
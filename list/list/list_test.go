@@ -197,3 +197,12 @@ func ExampleFilter() {
 	fmt.Printf("b = %v\n", b)
 	// output: b = [9, 10, 11]
 }
+
+func ExampleEquality() {
+	eq := list.Equality(equality.StringIgnoreCase())
+	fmt.Println(eq.Equal(list.New("a", "b"), list.New("A", "B")))
+	fmt.Println(eq.Equal(list.New("a", "b"), list.New("a")))
+	// output:
+	// true
+	// false
+}
@@ -0,0 +1,11 @@
+/*
+Package stream implements lazy, memoised streams, similar to lazy lists in Haskell.
+
+A Stream is a possibly infinite list, where each cell is only computed when it is needed.
+Computed cells are remembered, so that traversing a stream several times,
+or from several goroutines, evaluates each cell at most once.
+Forcing a cell is safe for concurrent use.
+
+Note that keeping a reference to the start of a stream keeps all evaluated cells in memory.
+*/
+package stream
@@ -0,0 +1,401 @@
+package stream
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/linked"
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// Stream is an immutable lazy list.
+// The zero value is an empty stream.
+type Stream[T any] struct {
+	// lazy is nil for the empty stream
+	lazy *lazyCell[T]
+}
+
+// lazyCell is a cell that is computed at most once.
+type lazyCell[T any] struct {
+	once  sync.Once
+	thunk func() cell[T]
+	value cell[T]
+	// panicked is set when the thunk panicked, in which case every access panics again
+	panicked   bool
+	panicValue any
+	// done is set after the value is computed, only used for printing
+	done bool
+	mu   sync.Mutex
+}
+
+// cell is an evaluated stream cell
+type cell[T any] struct {
+	nonEmpty bool
+	head     T
+	tail     Stream[T]
+}
+
+func fromThunk[T any](thunk func() cell[T]) Stream[T] {
+	return Stream[T]{&lazyCell[T]{thunk: thunk}}
+}
+
+func evaluated[T any](c cell[T]) Stream[T] {
+	l := &lazyCell[T]{value: c, done: true}
+	l.once.Do(func() {})
+	return Stream[T]{l}
+}
+
+// force evaluates the first cell of the stream
+func (s Stream[T]) force() cell[T] {
+	if s.lazy == nil {
+		return cell[T]{}
+	}
+	l := s.lazy
+	l.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				l.panicked = true
+				l.panicValue = r
+				l.thunk = nil
+				panic(r)
+			}
+		}()
+		l.value = l.thunk()
+		l.thunk = nil
+		l.mu.Lock()
+		l.done = true
+		l.mu.Unlock()
+	})
+	if l.panicked {
+		panic(l.panicValue)
+	}
+	return l.value
+}
+
+// isEvaluated checks whether the first cell is evaluated without forcing it
+func (s Stream[T]) isEvaluated() bool {
+	if s.lazy == nil {
+		return true
+	}
+	s.lazy.mu.Lock()
+	defer s.lazy.mu.Unlock()
+	return s.lazy.done
+}
+
+// Empty returns the empty stream
+func Empty[T any]() Stream[T] {
+	return Stream[T]{}
+}
+
+// Cons creates a stream with the given head and tail.
+func Cons[T any](head T, tail Stream[T]) Stream[T] {
+	return evaluated(cell[T]{nonEmpty: true, head: head, tail: tail})
+}
+
+// ConsLazy creates a stream with the given head and a tail that is computed when it is first needed.
+func ConsLazy[T any](head T, tail func() Stream[T]) Stream[T] {
+	return Cons(head, Defer(tail))
+}
+
+// Defer creates a stream that is computed by f when it is first needed.
+func Defer[T any](f func() Stream[T]) Stream[T] {
+	return fromThunk(func() cell[T] {
+		return f().force()
+	})
+}
+
+// Of creates a finite stream with the given elements.
+func Of[T any](elems ...T) Stream[T] {
+	res := Empty[T]()
+	for i := len(elems) - 1; i >= 0; i-- {
+		res = Cons(elems[i], res)
+	}
+	return res
+}
+
+// FromLinked creates a stream with the elements of a linked list.
+func FromLinked[T any](l *linked.List[T]) Stream[T] {
+	return fromThunk(func() cell[T] {
+		if l == nil {
+			return cell[T]{}
+		}
+		return cell[T]{nonEmpty: true, head: l.Head(), tail: FromLinked(l.Tail())}
+	})
+}
+
+// FromIterable creates a stream from an iterable.
+// The iterable is iterated at most once and only as far as the stream is evaluated.
+func FromIterable[T any](it iterable.Iterable[T]) Stream[T] {
+	if s, ok := it.(Stream[T]); ok {
+		return s
+	}
+	var iter iterable.Iterator[T]
+	var mu sync.Mutex
+	var next func() Stream[T]
+	next = func() Stream[T] {
+		return fromThunk(func() cell[T] {
+			// cells are forced in order, but the lock makes sure the iterator is only used by one goroutine at a time
+			mu.Lock()
+			defer mu.Unlock()
+			if iter == nil {
+				iter = it.Iterator()
+			}
+			x, ok := iter.Next()
+			if !ok {
+				return cell[T]{}
+			}
+			return cell[T]{nonEmpty: true, head: x, tail: next()}
+		})
+	}
+	return next()
+}
+
+// Iterate creates the infinite stream x, f(x), f(f(x)), ...
+func Iterate[T any](x T, f func(T) T) Stream[T] {
+	return ConsLazy(x, func() Stream[T] {
+		return Iterate(f(x), f)
+	})
+}
+
+// Unfold creates a stream from a state and a next function, which returns the next state and the next element.
+// The stream ends when the next function returns false.
+func Unfold[S, T any](initialState S, next func(state S) (S, T, bool)) Stream[T] {
+	return fromThunk(func() cell[T] {
+		s, x, ok := next(initialState)
+		if !ok {
+			return cell[T]{}
+		}
+		return cell[T]{nonEmpty: true, head: x, tail: Unfold(s, next)}
+	})
+}
+
+// Repeat creates an infinite stream repeating the given element.
+func Repeat[T any](x T) Stream[T] {
+	l := &lazyCell[T]{done: true}
+	l.value = cell[T]{nonEmpty: true, head: x, tail: Stream[T]{l}}
+	l.once.Do(func() {})
+	return Stream[T]{l}
+}
+
+// IsEmpty checks whether the stream has no elements.
+// This forces the first cell.
+func (s Stream[T]) IsEmpty() bool {
+	return !s.force().nonEmpty
+}
+
+// Uncons returns the head and tail of the stream or false if the stream is empty.
+func (s Stream[T]) Uncons() (T, Stream[T], bool) {
+	c := s.force()
+	return c.head, c.tail, c.nonEmpty
+}
+
+// Head returns the first element of the stream.
+// Panics if the stream is empty.
+func (s Stream[T]) Head() T {
+	c := s.force()
+	if !c.nonEmpty {
+		panic(fmt.Errorf("trying to get head of empty stream"))
+	}
+	return c.head
+}
+
+// Tail returns the stream without its first element.
+// Panics if the stream is empty.
+func (s Stream[T]) Tail() Stream[T] {
+	c := s.force()
+	if !c.nonEmpty {
+		panic(fmt.Errorf("trying to get tail of empty stream"))
+	}
+	return c.tail
+}
+
+// At returns the element at the given index or false if the stream is shorter.
+func (s Stream[T]) At(i int) (T, bool) {
+	c := s.Drop(i).force()
+	return c.head, c.nonEmpty
+}
+
+// Take returns a stream with the first n elements.
+func (s Stream[T]) Take(n int) Stream[T] {
+	if n <= 0 {
+		return Empty[T]()
+	}
+	return fromThunk(func() cell[T] {
+		c := s.force()
+		if !c.nonEmpty {
+			return c
+		}
+		return cell[T]{nonEmpty: true, head: c.head, tail: c.tail.Take(n - 1)}
+	})
+}
+
+// TakeWhile returns the longest prefix of the stream where all elements satisfy the condition.
+func (s Stream[T]) TakeWhile(cond func(T) bool) Stream[T] {
+	return fromThunk(func() cell[T] {
+		c := s.force()
+		if !c.nonEmpty || !cond(c.head) {
+			return cell[T]{}
+		}
+		return cell[T]{nonEmpty: true, head: c.head, tail: c.tail.TakeWhile(cond)}
+	})
+}
+
+// Drop returns the stream without the first n elements.
+// This forces the first n cells.
+func (s Stream[T]) Drop(n int) Stream[T] {
+	for ; n > 0; n-- {
+		c := s.force()
+		if !c.nonEmpty {
+			break
+		}
+		s = c.tail
+	}
+	return s
+}
+
+// DropWhile returns the stream without the longest prefix where all elements satisfy the condition.
+func (s Stream[T]) DropWhile(cond func(T) bool) Stream[T] {
+	return fromThunk(func() cell[T] {
+		current := s
+		for {
+			c := current.force()
+			if !c.nonEmpty || !cond(c.head) {
+				return c
+			}
+			current = c.tail
+		}
+	})
+}
+
+// Filter returns a stream with the elements that satisfy the condition.
+func (s Stream[T]) Filter(cond func(T) bool) Stream[T] {
+	return fromThunk(func() cell[T] {
+		current := s
+		for {
+			c := current.force()
+			if !c.nonEmpty {
+				return c
+			}
+			if cond(c.head) {
+				return cell[T]{nonEmpty: true, head: c.head, tail: c.tail.Filter(cond)}
+			}
+			current = c.tail
+		}
+	})
+}
+
+// Append returns a stream with the elements of this stream followed by the elements of the other stream.
+func (s Stream[T]) Append(other Stream[T]) Stream[T] {
+	return fromThunk(func() cell[T] {
+		c := s.force()
+		if !c.nonEmpty {
+			return other.force()
+		}
+		return cell[T]{nonEmpty: true, head: c.head, tail: c.tail.Append(other)}
+	})
+}
+
+// Map applies a function to each element of the stream.
+// The function is applied at most once per element, when the element is first needed.
+func Map[A, B any](s Stream[A], f func(A) B) Stream[B] {
+	return fromThunk(func() cell[B] {
+		c := s.force()
+		if !c.nonEmpty {
+			return cell[B]{}
+		}
+		return cell[B]{nonEmpty: true, head: f(c.head), tail: Map(c.tail, f)}
+	})
+}
+
+// ZipWith combines the elements of two streams pairwise.
+// The resulting stream ends when one of the streams ends.
+func ZipWith[A, B, C any](a Stream[A], b Stream[B], f func(A, B) C) Stream[C] {
+	return fromThunk(func() cell[C] {
+		ca := a.force()
+		if !ca.nonEmpty {
+			return cell[C]{}
+		}
+		cb := b.force()
+		if !cb.nonEmpty {
+			return cell[C]{}
+		}
+		return cell[C]{nonEmpty: true, head: f(ca.head, cb.head), tail: ZipWith(ca.tail, cb.tail, f)}
+	})
+}
+
+// Zip combines the elements of two streams into pairs.
+// The resulting stream ends when one of the streams ends.
+func Zip[A, B any](a Stream[A], b Stream[B]) Stream[hash.Pair[A, B]] {
+	return ZipWith(a, b, func(x A, y B) hash.Pair[A, B] {
+		return hash.Pair[A, B]{A: x, B: y}
+	})
+}
+
+// Iterator returns the elements of the stream, evaluating cells as needed.
+func (s Stream[T]) Iterator() iterable.Iterator[T] {
+	current := s
+	return iterable.Fun[T](func() (T, bool) {
+		c := current.force()
+		if !c.nonEmpty {
+			return zero.Value[T](), false
+		}
+		current = c.tail
+		return c.head, true
+	})
+}
+
+// ToLinked evaluates the whole stream and returns its elements as a linked list.
+// Does not terminate for infinite streams.
+func (s Stream[T]) ToLinked() *linked.List[T] {
+	return linked.FromIterable[T](s)
+}
+
+// ToSlice evaluates the whole stream and returns its elements as a slice.
+// Does not terminate for infinite streams.
+func (s Stream[T]) ToSlice() []T {
+	var res []T
+	for it := s.Iterator(); ; {
+		x, ok := it.Next()
+		if !ok {
+			return res
+		}
+		res = append(res, x)
+	}
+}
+
+// String shows the evaluated prefix of the stream, without evaluating any further cells.
+// Unevaluated parts are shown as "...".
+func (s Stream[T]) String() string {
+	var sb strings.Builder
+	sb.WriteString("[")
+	first := true
+	for current := s; ; {
+		if !current.isEvaluated() {
+			if !first {
+				sb.WriteString(", ")
+			}
+			sb.WriteString("...")
+			break
+		}
+		c := current.force()
+		if !c.nonEmpty {
+			break
+		}
+		if !first {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(fmt.Sprintf("%v", c.head))
+		first = false
+		if c.tail.lazy == current.lazy {
+			// infinite repetition of the same cell
+			sb.WriteString(", ...")
+			break
+		}
+		current = c.tail
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
@@ -0,0 +1,134 @@
+package stream_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/linked"
+	"github.com/peterzeller/go-fun/list/stream"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleIterate() {
+	powers := stream.Iterate(1, func(x int) int { return 2 * x })
+	fmt.Printf("%v\n", powers.Take(5).ToSlice())
+	fmt.Printf("%v\n", powers)
+	// output:
+	// [1 2 4 8 16]
+	// [1, 2, 4, 8, 16, ...]
+}
+
+func ExampleUnfold() {
+	type state struct{ a, b int }
+	fib := stream.Unfold(state{0, 1}, func(s state) (state, int, bool) {
+		return state{s.b, s.a + s.b}, s.a, true
+	})
+	fmt.Printf("%v\n", fib.Take(10).ToLinked())
+	// output:
+	// [0, 1, 1, 2, 3, 5, 8, 13, 21, 34]
+}
+
+func ExampleZip() {
+	names := stream.Of("a", "b", "c")
+	indexes := stream.Iterate(0, func(x int) int { return x + 1 })
+	for it := stream.Zip(names, indexes).Iterator(); ; {
+		p, ok := it.Next()
+		if !ok {
+			break
+		}
+		fmt.Printf("%v: %v\n", p.B, p.A)
+	}
+	// output:
+	// 0: a
+	// 1: b
+	// 2: c
+}
+
+func ExampleMap() {
+	calls := 0
+	squares := stream.Map(stream.Iterate(1, func(x int) int { return x + 1 }), func(x int) int {
+		calls++
+		return x * x
+	})
+	// the stream is evaluated twice, but each element is only computed once
+	fmt.Printf("%v\n", squares.Take(3).ToSlice())
+	fmt.Printf("%v\n", squares.Take(4).ToSlice())
+	fmt.Printf("calls: %v\n", calls)
+	// output:
+	// [1 4 9]
+	// [1 4 9 16]
+	// calls: 4
+}
+
+func TestFromIterableSinglePass(t *testing.T) {
+	iterations := 0
+	it := iterable.IterableFun[int](func() iterable.Iterator[int] {
+		iterations++
+		return iterable.Range(0, 5).Iterator()
+	})
+	s := stream.FromIterable[int](it)
+	require.Equal(t, 0, iterations)
+	require.Equal(t, []int{0, 1, 2, 3, 4}, s.ToSlice())
+	require.Equal(t, []int{0, 1, 2, 3, 4}, iterable.ToSlice[int](s))
+	require.Equal(t, 1, iterations)
+}
+
+func TestBasics(t *testing.T) {
+	s := stream.Cons(1, stream.Of(2, 3))
+	require.Equal(t, 1, s.Head())
+	require.Equal(t, 2, s.Tail().Head())
+	x, ok := s.At(2)
+	require.True(t, ok)
+	require.Equal(t, 3, x)
+	_, ok = s.At(3)
+	require.False(t, ok)
+	require.True(t, stream.Empty[int]().IsEmpty())
+	require.Panics(t, func() { stream.Empty[int]().Head() })
+	require.Panics(t, func() { stream.Empty[int]().Tail() })
+	require.Equal(t, []int{1, 2, 3, 2, 3}, s.Append(s.Tail()).ToSlice())
+	require.Equal(t, []int{1, 3}, s.Filter(func(x int) bool { return x%2 == 1 }).ToSlice())
+	require.Equal(t, []int{1, 2}, s.TakeWhile(func(x int) bool { return x < 3 }).ToSlice())
+	require.Equal(t, []int{3}, s.DropWhile(func(x int) bool { return x < 3 }).ToSlice())
+	require.Equal(t, []int{7, 7, 7}, stream.Repeat(7).Take(3).ToSlice())
+	require.Equal(t, []int{1, 2, 3}, stream.FromLinked(linked.New(1, 2, 3)).ToSlice())
+	require.Equal(t, "[...]", stream.Map(s, func(x int) int { return x }).String())
+}
+
+func TestConcurrentForcing(t *testing.T) {
+	var calls int32
+	s := stream.Map(stream.Iterate(0, func(x int) int { return x + 1 }), func(x int) int {
+		atomic.AddInt32(&calls, 1)
+		return x
+	})
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			require.Equal(t, 999, s.Drop(999).Head())
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1000), atomic.LoadInt32(&calls))
+}
+
+func TestPanicIsRemembered(t *testing.T) {
+	calls := 0
+	s := stream.Defer(func() stream.Stream[int] {
+		calls++
+		panic("boom")
+	})
+	require.PanicsWithValue(t, "boom", func() { s.Head() })
+	require.PanicsWithValue(t, "boom", func() { s.Head() })
+	require.Equal(t, 1, calls)
+}
+
+func TestLongStream(t *testing.T) {
+	s := stream.Iterate(0, func(x int) int { return x + 1 }).Filter(func(x int) bool { return x%100 == 0 })
+	x, ok := s.At(1000)
+	require.True(t, ok)
+	require.Equal(t, 100000, x)
+}
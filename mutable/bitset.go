@@ -0,0 +1,245 @@
+package mutable
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/linked"
+	"github.com/peterzeller/go-fun/list/list"
+	"github.com/peterzeller/go-fun/set/intset"
+)
+
+// Bitset is a mutable set of non-negative integers, stored as a bitmap.
+// The memory usage is proportional to the largest element.
+type Bitset struct {
+	words []uint64
+	size  int
+}
+
+var _ equality.Equal[*Bitset] = &Bitset{}
+
+// NewBitset creates a new bitset with the given elements.
+// Panics if an element is negative.
+func NewBitset(elems ...int) *Bitset {
+	b := &Bitset{}
+	for _, x := range elems {
+		b.Add(x)
+	}
+	return b
+}
+
+func checkBitsetElem(x int) {
+	if x < 0 {
+		panic(fmt.Errorf("bitset element must not be negative, but was %d", x))
+	}
+}
+
+// Add adds an element to the set.
+// Returns true if the element was not in the set before.
+// Panics if the element is negative.
+func (b *Bitset) Add(x int) bool {
+	checkBitsetElem(x)
+	w := x / 64
+	b.grow(w + 1)
+	bit := uint64(1) << (x % 64)
+	if b.words[w]&bit != 0 {
+		return false
+	}
+	b.words[w] |= bit
+	b.size++
+	return true
+}
+
+// grow ensures that the bitset has at least n words.
+// Spare capacity is reused, otherwise the capacity is doubled.
+func (b *Bitset) grow(n int) {
+	if n <= len(b.words) {
+		return
+	}
+	if n <= cap(b.words) {
+		old := len(b.words)
+		b.words = b.words[:n]
+		// words beyond the old length might contain stale bits, for example after Clear
+		for i := old; i < n; i++ {
+			b.words[i] = 0
+		}
+		return
+	}
+	newCap := 2 * cap(b.words)
+	if newCap < n {
+		newCap = n
+	}
+	words := make([]uint64, n, newCap)
+	copy(words, b.words)
+	b.words = words
+}
+
+// Remove removes an element from the set.
+// Returns true if the element was in the set before.
+func (b *Bitset) Remove(x int) bool {
+	if !b.Contains(x) {
+		return false
+	}
+	b.words[x/64] &^= uint64(1) << (x % 64)
+	b.size--
+	return true
+}
+
+// Contains checks whether the given element is in the set.
+func (b *Bitset) Contains(x int) bool {
+	if x < 0 || x/64 >= len(b.words) {
+		return false
+	}
+	return b.words[x/64]&(uint64(1)<<(x%64)) != 0
+}
+
+// Size returns the number of elements in the set
+func (b *Bitset) Size() int {
+	return b.size
+}
+
+// Empty checks whether the set has no elements
+func (b *Bitset) Empty() bool {
+	return b.size == 0
+}
+
+// Clear removes all elements from the set
+func (b *Bitset) Clear() {
+	b.words = b.words[:0]
+	b.size = 0
+}
+
+// UnionWith adds all elements from the other set to this set.
+func (b *Bitset) UnionWith(other *Bitset) {
+	b.grow(len(other.words))
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+	b.recount()
+}
+
+// IntersectWith removes all elements from this set that are not in the other set.
+func (b *Bitset) IntersectWith(other *Bitset) {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+	b.recount()
+}
+
+// MinusWith removes all elements from this set that are in the other set.
+func (b *Bitset) MinusWith(other *Bitset) {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &^= other.words[i]
+		}
+	}
+	b.recount()
+}
+
+func (b *Bitset) recount() {
+	b.size = 0
+	for _, w := range b.words {
+		b.size += bits.OnesCount64(w)
+	}
+}
+
+// Equal checks whether both bitsets contain the same elements.
+func (b *Bitset) Equal(other *Bitset) bool {
+	if b.size != other.size {
+		return false
+	}
+	n := len(b.words)
+	if len(other.words) < n {
+		n = len(other.words)
+	}
+	// since the sizes are equal, it is enough to compare the common words
+	for i := 0; i < n; i++ {
+		if b.words[i] != other.words[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Rank returns the number of elements that are smaller than x.
+func (b *Bitset) Rank(x int) int {
+	if x <= 0 {
+		return 0
+	}
+	res := 0
+	w := x / 64
+	for i := 0; i < w && i < len(b.words); i++ {
+		res += bits.OnesCount64(b.words[i])
+	}
+	if w < len(b.words) {
+		res += bits.OnesCount64(b.words[w] & (uint64(1)<<(x%64) - 1))
+	}
+	return res
+}
+
+// Select returns the element at the given position in ascending order, starting at 0.
+// Returns false if the index is out of range.
+func (b *Bitset) Select(i int) (int, bool) {
+	if i < 0 || i >= b.size {
+		return 0, false
+	}
+	for wi, w := range b.words {
+		c := bits.OnesCount64(w)
+		if i < c {
+			for ; i > 0; i-- {
+				w &= w - 1
+			}
+			return wi*64 + bits.TrailingZeros64(w), true
+		}
+		i -= c
+	}
+	return 0, false
+}
+
+// Iterator returns the elements in ascending order.
+// The set must not be modified while iterating.
+func (b *Bitset) Iterator() iterable.Iterator[int] {
+	wi := 0
+	var w uint64
+	if len(b.words) > 0 {
+		w = b.words[0]
+	}
+	return iterable.Fun[int](func() (int, bool) {
+		for w == 0 {
+			wi++
+			if wi >= len(b.words) {
+				return 0, false
+			}
+			w = b.words[wi]
+		}
+		res := wi*64 + bits.TrailingZeros64(w)
+		w &= w - 1
+		return res, true
+	})
+}
+
+// ToIntSet returns the elements as an immutable integer set.
+func (b *Bitset) ToIntSet() intset.Set {
+	return intset.FromIterable(b)
+}
+
+// ToList returns the elements as an immutable list in ascending order.
+func (b *Bitset) ToList() list.List[int] {
+	return list.FromIterable[int](b)
+}
+
+// ToLinkedList returns the elements as an immutable linked list in ascending order.
+func (b *Bitset) ToLinkedList() *linked.List[int] {
+	return linked.FromIterable[int](b)
+}
+
+// String implements the fmt.Stringer interface
+func (b *Bitset) String() string {
+	return iterable.String[int](b)
+}
@@ -0,0 +1,110 @@
+package mutable_test
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/mutable"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+func ExampleBitset() {
+	b := mutable.NewBitset(3, 1, 200)
+	b.Add(64)
+	b.Remove(3)
+	fmt.Printf("%v, size = %v\n", b, b.Size())
+	fmt.Printf("rank(100) = %v\n", b.Rank(100))
+	other := mutable.NewBitset(1, 2, 200)
+	b.IntersectWith(other)
+	fmt.Printf("intersection: %v\n", b.ToIntSet())
+	// output:
+	// [1, 64, 200], size = 3
+	// rank(100) = 2
+	// intersection: [1, 200]
+}
+
+func TestBitset(t *testing.T) {
+	genElems := rapid.SliceOf(rapid.IntRange(0, 300))
+	rapid.Check(t, func(t *rapid.T) {
+		xs := genElems.Draw(t, "xs").([]int)
+		ys := genElems.Draw(t, "ys").([]int)
+		model := make(map[int]bool)
+		for _, x := range xs {
+			model[x] = true
+		}
+		b := mutable.NewBitset(xs...)
+		other := mutable.NewBitset(ys...)
+		otherModel := make(map[int]bool)
+		for _, y := range ys {
+			otherModel[y] = true
+		}
+		switch rapid.IntRange(0, 3).Draw(t, "op").(int) {
+		case 0:
+			b.UnionWith(other)
+			for y := range otherModel {
+				model[y] = true
+			}
+		case 1:
+			b.IntersectWith(other)
+			for x := range model {
+				if !otherModel[x] {
+					delete(model, x)
+				}
+			}
+		case 2:
+			b.MinusWith(other)
+			for y := range otherModel {
+				delete(model, y)
+			}
+		case 3:
+			for _, y := range ys {
+				require.Equal(t, model[y], b.Remove(y))
+				delete(model, y)
+			}
+		}
+		sorted := []int{}
+		for x := range model {
+			sorted = append(sorted, x)
+		}
+		sort.Ints(sorted)
+		require.Equal(t, len(sorted), b.Size())
+		require.Equal(t, sorted, append([]int{}, iterable.ToSlice[int](b)...))
+		for i, x := range sorted {
+			require.Equal(t, i, b.Rank(x))
+			s, ok := b.Select(i)
+			require.True(t, ok)
+			require.Equal(t, x, s)
+			require.True(t, b.Contains(x))
+		}
+		_, ok := b.Select(len(sorted))
+		require.False(t, ok)
+		require.True(t, b.ToIntSet().Equal(b.ToIntSet()))
+		require.True(t, b.Equal(mutable.NewBitset(sorted...)))
+		require.Equal(t, reflect.DeepEqual(model, otherModel), b.Equal(other))
+		require.Equal(t, len(sorted), b.ToIntSet().Size())
+	})
+}
+
+func TestBitsetNegative(t *testing.T) {
+	b := mutable.NewBitset()
+	require.Panics(t, func() { b.Add(-1) })
+	require.False(t, b.Contains(-1))
+	require.False(t, b.Remove(-1))
+}
+
+func TestBitsetClearAndGrow(t *testing.T) {
+	b := mutable.NewBitset(1, 100, 500)
+	b.Clear()
+	b.Add(600)
+	require.Equal(t, []int{600}, iterable.ToSlice[int](b))
+	b.UnionWith(mutable.NewBitset(3))
+	require.Equal(t, []int{3, 600}, iterable.ToSlice[int](b))
+	for i := 0; i < 2000; i++ {
+		b.Add(i)
+	}
+	require.Equal(t, 2000, b.Size())
+}
@@ -0,0 +1,120 @@
+package mutable
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/linked"
+	"github.com/peterzeller/go-fun/list/list"
+)
+
+// Deque is a mutable double-ended queue backed by a growable circular buffer.
+// Elements can be added and removed at both ends in amortized constant time.
+type Deque[T any] struct {
+	r ring[T]
+}
+
+// NewDeque creates a new deque with the given elements.
+// The first element is at the front of the deque.
+func NewDeque[T any](elems ...T) *Deque[T] {
+	return &Deque[T]{
+		r: newRing(len(elems), elems),
+	}
+}
+
+// PushFront adds an element at the front of the deque
+func (d *Deque[T]) PushFront(x T) {
+	if d.r.full() {
+		d.r.grow()
+	}
+	d.r.pushFront(x)
+}
+
+// PushBack adds an element at the back of the deque
+func (d *Deque[T]) PushBack(x T) {
+	if d.r.full() {
+		d.r.grow()
+	}
+	d.r.pushBack(x)
+}
+
+// First returns the element at the front of the deque without removing it.
+// Panics if the deque is empty.
+func (d *Deque[T]) First() T {
+	if d.r.size == 0 {
+		panic(fmt.Errorf("first on an empty deque"))
+	}
+	return d.r.at(0)
+}
+
+// Last returns the element at the back of the deque without removing it.
+// Panics if the deque is empty.
+func (d *Deque[T]) Last() T {
+	if d.r.size == 0 {
+		panic(fmt.Errorf("last on an empty deque"))
+	}
+	return d.r.at(d.r.size - 1)
+}
+
+// PopFront removes and returns the element at the front of the deque.
+// Panics if the deque is empty.
+func (d *Deque[T]) PopFront() T {
+	if d.r.size == 0 {
+		panic(fmt.Errorf("popping from an empty deque"))
+	}
+	return d.r.popFront()
+}
+
+// PopBack removes and returns the element at the back of the deque.
+// Panics if the deque is empty.
+func (d *Deque[T]) PopBack() T {
+	if d.r.size == 0 {
+		panic(fmt.Errorf("popping from an empty deque"))
+	}
+	return d.r.popBack()
+}
+
+// At returns the element at the given position, counted from the front.
+// Panics if the index is out of range.
+func (d *Deque[T]) At(i int) T {
+	if i < 0 || i >= d.r.size {
+		panic(fmt.Errorf("index %d out of range for deque of size %d", i, d.r.size))
+	}
+	return d.r.at(i)
+}
+
+// Empty checks whether the deque has no elements
+func (d *Deque[T]) Empty() bool {
+	return d.r.size == 0
+}
+
+// Size returns the number of elements in the deque
+func (d *Deque[T]) Size() int {
+	return d.r.size
+}
+
+// Clear removes all elements from the deque
+func (d *Deque[T]) Clear() {
+	d.r.clear()
+}
+
+// Iterator returns the elements from the front to the back of the deque.
+// The deque must not be modified while iterating.
+func (d *Deque[T]) Iterator() iterable.Iterator[T] {
+	return d.r.iterator()
+}
+
+// ToList returns the elements as an immutable list, starting with the front element.
+func (d *Deque[T]) ToList() list.List[T] {
+	return list.FromIterable[T](d)
+}
+
+// ToLinkedList returns the elements as an immutable linked list, starting with the front element.
+func (d *Deque[T]) ToLinkedList() *linked.List[T] {
+	return linked.FromIterable[T](d)
+}
+
+// String implements the fmt.Stringer interface
+func (d *Deque[T]) String() string {
+	return iterable.String[T](d)
+}
@@ -0,0 +1,74 @@
+package mutable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/mutable"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+func ExampleDeque() {
+	d := mutable.NewDeque(2, 3)
+	d.PushFront(1)
+	d.PushBack(4)
+	fmt.Printf("deque = %v\n", d)
+	fmt.Printf("first = %v, last = %v, at(1) = %v\n", d.First(), d.Last(), d.At(1))
+	fmt.Printf("popFront = %v, popBack = %v\n", d.PopFront(), d.PopBack())
+	fmt.Printf("deque = %v\n", d)
+	// output:
+	// deque = [1, 2, 3, 4]
+	// first = 1, last = 4, at(1) = 2
+	// popFront = 1, popBack = 4
+	// deque = [2, 3]
+}
+
+func TestDeque(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		initial := rapid.SliceOf(rapid.IntRange(-20, 20)).Draw(t, "initial").([]int)
+		model := append([]int{}, initial...)
+		d := mutable.NewDeque(initial...)
+		n := rapid.IntRange(0, 50).Draw(t, "n").(int)
+		for i := 0; i < n; i++ {
+			switch rapid.IntRange(0, 4).Draw(t, "op").(int) {
+			case 0:
+				x := rapid.IntRange(-20, 20).Draw(t, "x").(int)
+				d.PushFront(x)
+				model = append([]int{x}, model...)
+			case 1:
+				x := rapid.IntRange(-20, 20).Draw(t, "x").(int)
+				d.PushBack(x)
+				model = append(model, x)
+			case 2:
+				if len(model) == 0 {
+					require.Panics(t, func() { d.PopFront() })
+					continue
+				}
+				require.Equal(t, model[0], d.First())
+				require.Equal(t, model[0], d.PopFront())
+				model = model[1:]
+			case 3:
+				if len(model) == 0 {
+					require.Panics(t, func() { d.PopBack() })
+					continue
+				}
+				require.Equal(t, model[len(model)-1], d.Last())
+				require.Equal(t, model[len(model)-1], d.PopBack())
+				model = model[:len(model)-1]
+			case 4:
+				if len(model) == 0 {
+					require.Panics(t, func() { d.At(0) })
+					continue
+				}
+				j := rapid.IntRange(0, len(model)-1).Draw(t, "j").(int)
+				require.Equal(t, model[j], d.At(j))
+			}
+			require.Equal(t, len(model), d.Size())
+		}
+		require.Equal(t, model, append([]int{}, iterable.ToSlice[int](d)...))
+		require.Equal(t, model, append([]int{}, iterable.ToSlice[int](d.ToList())...))
+		require.Equal(t, model, append([]int{}, d.ToLinkedList().ToSlice()...))
+	})
+}
@@ -0,0 +1,9 @@
+/*
+Package mutable provides mutable data structures: Stack, Queue, Deque, RingBuffer, Heap and Bitset.
+
+All data structures implement iterable.Iterable and can be converted to the immutable list.List and linked.List types.
+
+The data structures are not safe for concurrent use.
+Use the immutable data structures from the other packages if values need to be shared.
+*/
+package mutable
@@ -0,0 +1,153 @@
+package mutable
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/linked"
+	"github.com/peterzeller/go-fun/list/list"
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// Heap is a mutable binary heap.
+// The order of elements is determined by the less function given when creating the heap.
+type Heap[T any] struct {
+	elems []T
+	less  func(a, b T) bool
+}
+
+// NewHeap creates a new heap with the given elements.
+// The less function determines the order of the elements: The smallest element is returned first.
+// The heap takes ownership of the elems slice.
+func NewHeap[T any](less func(a, b T) bool, elems ...T) *Heap[T] {
+	h := &Heap[T]{
+		elems: elems,
+		less:  less,
+	}
+	for i := len(elems)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// Push adds an element to the heap
+func (h *Heap[T]) Push(x T) {
+	h.elems = append(h.elems, x)
+	h.up(len(h.elems) - 1)
+}
+
+// Peek returns the smallest element without removing it.
+// Panics if the heap is empty.
+func (h *Heap[T]) Peek() T {
+	if len(h.elems) == 0 {
+		panic(fmt.Errorf("peek on an empty heap"))
+	}
+	return h.elems[0]
+}
+
+// Pop removes and returns the smallest element.
+// Panics if the heap is empty.
+func (h *Heap[T]) Pop() T {
+	if len(h.elems) == 0 {
+		panic(fmt.Errorf("popping from an empty heap"))
+	}
+	res := h.elems[0]
+	last := len(h.elems) - 1
+	h.elems[0] = h.elems[last]
+	h.elems[last] = zero.Value[T]()
+	h.elems = h.elems[:last]
+	if last > 0 {
+		h.down(0)
+	}
+	return res
+}
+
+// Empty checks whether the heap has no elements
+func (h *Heap[T]) Empty() bool {
+	return len(h.elems) == 0
+}
+
+// Size returns the number of elements in the heap
+func (h *Heap[T]) Size() int {
+	return len(h.elems)
+}
+
+// Iterator returns the elements in priority order, starting with the smallest element.
+// Iterating does not change the heap, but it copies the elements of the heap.
+func (h *Heap[T]) Iterator() iterable.Iterator[T] {
+	c := &Heap[T]{
+		elems: append([]T(nil), h.elems...),
+		less:  h.less,
+	}
+	return c.Drain().Iterator()
+}
+
+// ToList returns the elements as an immutable list in priority order.
+func (h *Heap[T]) ToList() list.List[T] {
+	return list.FromIterable[T](h)
+}
+
+// ToLinkedList returns the elements as an immutable linked list in priority order.
+func (h *Heap[T]) ToLinkedList() *linked.List[T] {
+	return linked.FromIterable[T](h)
+}
+
+// String implements the fmt.Stringer interface
+func (h *Heap[T]) String() string {
+	return iterable.String[T](h)
+}
+
+// Drain returns an iterable that removes the elements from the heap in priority order.
+func (h *Heap[T]) Drain() iterable.Iterable[T] {
+	return heapDrain[T]{h}
+}
+
+// heapDrain is an iterable that removes elements from the heap
+type heapDrain[T any] struct {
+	h *Heap[T]
+}
+
+// Size returns the number of remaining elements
+func (d heapDrain[T]) Size() int {
+	return d.h.Size()
+}
+
+func (d heapDrain[T]) Iterator() iterable.Iterator[T] {
+	return iterable.Fun[T](func() (T, bool) {
+		if d.h.Empty() {
+			return zero.Value[T](), false
+		}
+		return d.h.Pop(), true
+	})
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.elems[i], h.elems[parent]) {
+			return
+		}
+		h.elems[i], h.elems[parent] = h.elems[parent], h.elems[i]
+		i = parent
+	}
+}
+
+func (h *Heap[T]) down(i int) {
+	n := len(h.elems)
+	for {
+		smallest := i
+		left := 2*i + 1
+		right := left + 1
+		if left < n && h.less(h.elems[left], h.elems[smallest]) {
+			smallest = left
+		}
+		if right < n && h.less(h.elems[right], h.elems[smallest]) {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		h.elems[i], h.elems[smallest] = h.elems[smallest], h.elems[i]
+		i = smallest
+	}
+}
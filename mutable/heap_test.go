@@ -0,0 +1,50 @@
+package mutable_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/mutable"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+func ExampleHeap() {
+	h := mutable.NewHeap(func(a, b int) bool { return a < b }, 5, 2, 8)
+	h.Push(1)
+	fmt.Printf("peek = %v\n", h.Peek())
+	fmt.Printf("drained: %v\n", iterable.ToSlice(h.Drain()))
+	fmt.Printf("empty = %v\n", h.Empty())
+	// output:
+	// peek = 1
+	// drained: [1 2 5 8]
+	// empty = true
+}
+
+func TestHeap(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		initial := rapid.SliceOf(rapid.IntRange(-20, 20)).Draw(t, "initial").([]int)
+		model := append([]int{}, initial...)
+		h := mutable.NewHeap(func(a, b int) bool { return a < b }, initial...)
+		n := rapid.IntRange(0, 50).Draw(t, "n").(int)
+		for i := 0; i < n; i++ {
+			sort.Ints(model)
+			if len(model) > 0 && rapid.Bool().Draw(t, "pop").(bool) {
+				require.Equal(t, model[0], h.Pop())
+				model = model[1:]
+			} else {
+				x := rapid.IntRange(-20, 20).Draw(t, "x").(int)
+				h.Push(x)
+				model = append(model, x)
+			}
+			require.Equal(t, len(model), h.Size())
+		}
+		sort.Ints(model)
+		require.Equal(t, model, append([]int{}, iterable.ToSlice[int](h)...))
+		require.Equal(t, len(model), h.Size())
+		require.Equal(t, model, append([]int{}, iterable.ToSlice(h.Drain())...))
+		require.True(t, h.Empty())
+	})
+}
@@ -0,0 +1,84 @@
+package mutable
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/linked"
+	"github.com/peterzeller/go-fun/list/list"
+)
+
+// Queue is a mutable first-in-first-out queue backed by a growable circular buffer.
+type Queue[T any] struct {
+	r ring[T]
+}
+
+// NewQueue creates a new queue with the given elements.
+// The first element is at the front of the queue.
+func NewQueue[T any](elems ...T) *Queue[T] {
+	return &Queue[T]{
+		r: newRing(len(elems), elems),
+	}
+}
+
+// Push adds an element at the back of the queue
+func (q *Queue[T]) Push(x T) {
+	if q.r.full() {
+		q.r.grow()
+	}
+	q.r.pushBack(x)
+}
+
+// Peek returns the element at the front of the queue without removing it.
+// Panics if the queue is empty.
+func (q *Queue[T]) Peek() T {
+	if q.r.size == 0 {
+		panic(fmt.Errorf("peek on an empty queue"))
+	}
+	return q.r.at(0)
+}
+
+// Pop removes and returns the element at the front of the queue.
+// Panics if the queue is empty.
+func (q *Queue[T]) Pop() T {
+	if q.r.size == 0 {
+		panic(fmt.Errorf("popping from an empty queue"))
+	}
+	return q.r.popFront()
+}
+
+// Empty checks whether the queue has no elements
+func (q *Queue[T]) Empty() bool {
+	return q.r.size == 0
+}
+
+// Size returns the number of elements in the queue
+func (q *Queue[T]) Size() int {
+	return q.r.size
+}
+
+// Clear removes all elements from the queue
+func (q *Queue[T]) Clear() {
+	q.r.clear()
+}
+
+// Iterator returns the elements from the front to the back of the queue.
+// The queue must not be modified while iterating.
+func (q *Queue[T]) Iterator() iterable.Iterator[T] {
+	return q.r.iterator()
+}
+
+// ToList returns the elements as an immutable list, starting with the front element.
+func (q *Queue[T]) ToList() list.List[T] {
+	return list.FromIterable[T](q)
+}
+
+// ToLinkedList returns the elements as an immutable linked list, starting with the front element.
+func (q *Queue[T]) ToLinkedList() *linked.List[T] {
+	return linked.FromIterable[T](q)
+}
+
+// String implements the fmt.Stringer interface
+func (q *Queue[T]) String() string {
+	return iterable.String[T](q)
+}
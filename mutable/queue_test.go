@@ -0,0 +1,44 @@
+package mutable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/peterzeller/go-fun/mutable"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleQueue() {
+	q := mutable.NewQueue(1, 2)
+	q.Push(3)
+	fmt.Printf("peek = %v, size = %v\n", q.Peek(), q.Size())
+	fmt.Printf("pop = %v\n", q.Pop())
+	fmt.Printf("list = %v\n", q.ToList())
+	// output:
+	// peek = 1, size = 3
+	// pop = 1
+	// list = [2, 3]
+}
+
+func TestQueue(t *testing.T) {
+	q := mutable.NewQueue[int]()
+	require.True(t, q.Empty())
+	require.Panics(t, func() { q.Pop() })
+	require.Panics(t, func() { q.Peek() })
+	next := 0
+	// interleave pushes and pops so that the buffer wraps around
+	for i := 0; i < 100; i++ {
+		q.Push(2 * i)
+		q.Push(2*i + 1)
+		require.Equal(t, next, q.Pop())
+		next++
+	}
+	require.Equal(t, 100, q.Size())
+	require.Equal(t, 100, q.ToLinkedList().Length())
+	for !q.Empty() {
+		require.Equal(t, next, q.Peek())
+		require.Equal(t, next, q.Pop())
+		next++
+	}
+	require.Equal(t, 200, next)
+}
@@ -0,0 +1,106 @@
+package mutable
+
+import (
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// ring is a circular buffer, which is used to implement Queue, Deque and RingBuffer.
+type ring[T any] struct {
+	elems []T
+	start int
+	size  int
+}
+
+func newRing[T any](capacity int, elems []T) ring[T] {
+	if capacity < len(elems) {
+		capacity = len(elems)
+	}
+	r := ring[T]{
+		elems: make([]T, capacity),
+		size:  len(elems),
+	}
+	copy(r.elems, elems)
+	return r
+}
+
+// index translates a logical position to a position in the elems slice
+func (r *ring[T]) index(i int) int {
+	i += r.start
+	if i >= len(r.elems) {
+		i -= len(r.elems)
+	}
+	return i
+}
+
+func (r *ring[T]) full() bool {
+	return r.size == len(r.elems)
+}
+
+// grow doubles the capacity and moves the elements to the start of the new slice
+func (r *ring[T]) grow() {
+	newCap := 2 * len(r.elems)
+	if newCap < 4 {
+		newCap = 4
+	}
+	elems := make([]T, newCap)
+	n := copy(elems, r.elems[r.start:])
+	copy(elems[n:], r.elems[:r.start])
+	r.elems = elems
+	r.start = 0
+}
+
+func (r *ring[T]) at(i int) T {
+	return r.elems[r.index(i)]
+}
+
+func (r *ring[T]) pushBack(x T) {
+	r.elems[r.index(r.size)] = x
+	r.size++
+}
+
+func (r *ring[T]) pushFront(x T) {
+	r.start--
+	if r.start < 0 {
+		r.start += len(r.elems)
+	}
+	r.elems[r.start] = x
+	r.size++
+}
+
+func (r *ring[T]) popFront() T {
+	res := r.elems[r.start]
+	r.elems[r.start] = zero.Value[T]()
+	r.start = r.index(1)
+	r.size--
+	return res
+}
+
+func (r *ring[T]) popBack() T {
+	i := r.index(r.size - 1)
+	res := r.elems[i]
+	r.elems[i] = zero.Value[T]()
+	r.size--
+	return res
+}
+
+func (r *ring[T]) clear() {
+	for i := range r.elems {
+		r.elems[i] = zero.Value[T]()
+	}
+	r.start = 0
+	r.size = 0
+}
+
+// iterator returns the elements from front to back
+func (r *ring[T]) iterator() iterable.Iterator[T] {
+	i := 0
+	return iterable.Fun[T](func() (T, bool) {
+		if i >= r.size {
+			return zero.Value[T](), false
+		}
+		res := r.at(i)
+		i++
+		return res, true
+	})
+}
@@ -0,0 +1,120 @@
+package mutable
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/linked"
+	"github.com/peterzeller/go-fun/list/list"
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// RingBuffer is a mutable first-in-first-out buffer with a fixed capacity.
+// When the buffer is full, adding an element overwrites the oldest element.
+type RingBuffer[T any] struct {
+	r ring[T]
+}
+
+// NewRingBuffer creates a new empty ring buffer with the given capacity.
+// Panics if the capacity is not positive.
+func NewRingBuffer[T any](capacity int) *RingBuffer[T] {
+	if capacity <= 0 {
+		panic(fmt.Errorf("ring buffer capacity must be positive, but was %d", capacity))
+	}
+	return &RingBuffer[T]{
+		r: newRing[T](capacity, nil),
+	}
+}
+
+// Push adds an element at the back of the buffer.
+// If the buffer is full, the oldest element is removed and returned with ok = true.
+func (b *RingBuffer[T]) Push(x T) (evicted T, ok bool) {
+	if b.r.full() {
+		evicted = b.r.popFront()
+		ok = true
+	}
+	b.r.pushBack(x)
+	return
+}
+
+// Peek returns the oldest element without removing it.
+// Panics if the buffer is empty.
+func (b *RingBuffer[T]) Peek() T {
+	if b.r.size == 0 {
+		panic(fmt.Errorf("peek on an empty ring buffer"))
+	}
+	return b.r.at(0)
+}
+
+// Pop removes and returns the oldest element.
+// Panics if the buffer is empty.
+func (b *RingBuffer[T]) Pop() T {
+	if b.r.size == 0 {
+		panic(fmt.Errorf("popping from an empty ring buffer"))
+	}
+	return b.r.popFront()
+}
+
+// TryPop removes and returns the oldest element.
+// Returns false if the buffer is empty.
+func (b *RingBuffer[T]) TryPop() (T, bool) {
+	if b.r.size == 0 {
+		return zero.Value[T](), false
+	}
+	return b.r.popFront(), true
+}
+
+// At returns the element at the given position, where 0 is the oldest element.
+// Panics if the index is out of range.
+func (b *RingBuffer[T]) At(i int) T {
+	if i < 0 || i >= b.r.size {
+		panic(fmt.Errorf("index %d out of range for ring buffer of size %d", i, b.r.size))
+	}
+	return b.r.at(i)
+}
+
+// Empty checks whether the buffer has no elements
+func (b *RingBuffer[T]) Empty() bool {
+	return b.r.size == 0
+}
+
+// Full checks whether the buffer has reached its capacity
+func (b *RingBuffer[T]) Full() bool {
+	return b.r.full()
+}
+
+// Size returns the number of elements in the buffer
+func (b *RingBuffer[T]) Size() int {
+	return b.r.size
+}
+
+// Capacity returns the maximum number of elements in the buffer
+func (b *RingBuffer[T]) Capacity() int {
+	return len(b.r.elems)
+}
+
+// Clear removes all elements from the buffer
+func (b *RingBuffer[T]) Clear() {
+	b.r.clear()
+}
+
+// Iterator returns the elements from the oldest to the newest.
+// The buffer must not be modified while iterating.
+func (b *RingBuffer[T]) Iterator() iterable.Iterator[T] {
+	return b.r.iterator()
+}
+
+// ToList returns the elements as an immutable list, starting with the oldest element.
+func (b *RingBuffer[T]) ToList() list.List[T] {
+	return list.FromIterable[T](b)
+}
+
+// ToLinkedList returns the elements as an immutable linked list, starting with the oldest element.
+func (b *RingBuffer[T]) ToLinkedList() *linked.List[T] {
+	return linked.FromIterable[T](b)
+}
+
+// String implements the fmt.Stringer interface
+func (b *RingBuffer[T]) String() string {
+	return iterable.String[T](b)
+}
@@ -0,0 +1,69 @@
+package mutable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/mutable"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+func ExampleRingBuffer() {
+	b := mutable.NewRingBuffer[int](3)
+	for i := 1; i <= 4; i++ {
+		if evicted, ok := b.Push(i); ok {
+			fmt.Printf("evicted %v\n", evicted)
+		}
+	}
+	fmt.Printf("buffer = %v, full = %v\n", b, b.Full())
+	fmt.Printf("pop = %v\n", b.Pop())
+	fmt.Printf("buffer = %v, full = %v\n", b, b.Full())
+	// output:
+	// evicted 1
+	// buffer = [2, 3, 4], full = true
+	// pop = 2
+	// buffer = [3, 4], full = false
+}
+
+func TestRingBuffer(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		capacity := rapid.IntRange(1, 10).Draw(t, "capacity").(int)
+		b := mutable.NewRingBuffer[int](capacity)
+		var model []int
+		n := rapid.IntRange(0, 50).Draw(t, "n").(int)
+		for i := 0; i < n; i++ {
+			if len(model) > 0 && rapid.Bool().Draw(t, "pop").(bool) {
+				require.Equal(t, model[0], b.Peek())
+				require.Equal(t, model[0], b.Pop())
+				model = model[1:]
+			} else {
+				x := rapid.IntRange(-20, 20).Draw(t, "x").(int)
+				evicted, ok := b.Push(x)
+				if len(model) == capacity {
+					require.True(t, ok)
+					require.Equal(t, model[0], evicted)
+					model = model[1:]
+				} else {
+					require.False(t, ok)
+				}
+				model = append(model, x)
+			}
+			require.Equal(t, len(model), b.Size())
+			require.Equal(t, len(model) == capacity, b.Full())
+			require.Equal(t, capacity, b.Capacity())
+		}
+		require.Equal(t, append([]int{}, model...), append([]int{}, iterable.ToSlice[int](b)...))
+		require.Equal(t, append([]int{}, model...), append([]int{}, b.ToLinkedList().ToSlice()...))
+		for i, x := range model {
+			require.Equal(t, x, b.At(i))
+		}
+		_, ok := b.TryPop()
+		require.Equal(t, len(model) > 0, ok)
+	})
+}
+
+func TestRingBufferInvalidCapacity(t *testing.T) {
+	require.Panics(t, func() { mutable.NewRingBuffer[int](0) })
+}
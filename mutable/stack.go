@@ -0,0 +1,97 @@
+package mutable
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/linked"
+	"github.com/peterzeller/go-fun/list/list"
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// Stack is a mutable last-in-first-out stack backed by a slice.
+type Stack[T any] struct {
+	elems []T
+}
+
+// NewStack creates a new stack with the given elements.
+// The last element is on the top of the stack.
+// The stack takes ownership of the elems slice.
+func NewStack[T any](elems ...T) *Stack[T] {
+	return &Stack[T]{
+		elems: elems,
+	}
+}
+
+// Push adds an element on top of the stack
+func (s *Stack[T]) Push(x T) {
+	s.elems = append(s.elems, x)
+}
+
+// Peek returns the top element without removing it.
+// Panics if the stack is empty.
+func (s *Stack[T]) Peek() T {
+	if len(s.elems) == 0 {
+		panic(fmt.Errorf("peek on an empty stack"))
+	}
+	return s.elems[len(s.elems)-1]
+}
+
+// Pop removes and returns the top element.
+// Panics if the stack is empty.
+func (s *Stack[T]) Pop() T {
+	if len(s.elems) == 0 {
+		panic(fmt.Errorf("popping from an empty stack"))
+	}
+	last := len(s.elems) - 1
+	res := s.elems[last]
+	s.elems[last] = zero.Value[T]()
+	s.elems = s.elems[:last]
+	return res
+}
+
+// Empty checks whether the stack has no elements
+func (s *Stack[T]) Empty() bool {
+	return len(s.elems) == 0
+}
+
+// Size returns the number of elements on the stack
+func (s *Stack[T]) Size() int {
+	return len(s.elems)
+}
+
+// Clear removes all elements from the stack
+func (s *Stack[T]) Clear() {
+	for i := range s.elems {
+		s.elems[i] = zero.Value[T]()
+	}
+	s.elems = s.elems[:0]
+}
+
+// Iterator returns the elements from the top to the bottom of the stack.
+// The stack must not be modified while iterating.
+func (s *Stack[T]) Iterator() iterable.Iterator[T] {
+	i := len(s.elems)
+	return iterable.Fun[T](func() (T, bool) {
+		if i <= 0 {
+			return zero.Value[T](), false
+		}
+		i--
+		return s.elems[i], true
+	})
+}
+
+// ToList returns the elements as an immutable list, starting with the top element.
+func (s *Stack[T]) ToList() list.List[T] {
+	return list.FromIterable[T](s)
+}
+
+// ToLinkedList returns the elements as an immutable linked list, starting with the top element.
+func (s *Stack[T]) ToLinkedList() *linked.List[T] {
+	return linked.FromIterable[T](s)
+}
+
+// String implements the fmt.Stringer interface
+func (s *Stack[T]) String() string {
+	return iterable.String[T](s)
+}
@@ -0,0 +1,44 @@
+package mutable_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/peterzeller/go-fun/mutable"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleStack() {
+	s := mutable.NewStack(1, 2)
+	s.Push(3)
+	fmt.Printf("peek = %v, size = %v\n", s.Peek(), s.Size())
+	fmt.Printf("stack = %v\n", s)
+	fmt.Printf("pop = %v\n", s.Pop())
+	fmt.Printf("list = %v\n", s.ToLinkedList())
+	// output:
+	// peek = 3, size = 3
+	// stack = [3, 2, 1]
+	// pop = 3
+	// list = [2, 1]
+}
+
+func TestStack(t *testing.T) {
+	s := mutable.NewStack[int]()
+	require.True(t, s.Empty())
+	require.Panics(t, func() { s.Pop() })
+	require.Panics(t, func() { s.Peek() })
+	for i := 0; i < 100; i++ {
+		s.Push(i)
+	}
+	require.Equal(t, 100, s.Size())
+	require.Equal(t, 100, s.ToList().Length())
+	require.Equal(t, 99, s.ToList().At(0))
+	for i := 99; i >= 0; i-- {
+		require.Equal(t, i, s.Peek())
+		require.Equal(t, i, s.Pop())
+	}
+	require.True(t, s.Empty())
+	s.Push(1)
+	s.Clear()
+	require.True(t, s.Empty())
+}
@@ -0,0 +1,105 @@
+package opt
+
+import (
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/list"
+)
+
+// Map applies a function to the value, if present
+func Map[A, B any](o Optional[A], f func(A) B) Optional[B] {
+	if o.present {
+		return Some(f(o.value))
+	}
+	return None[B]()
+}
+
+// FlatMap applies a function returning an optional value to the value, if present
+func FlatMap[A, B any](o Optional[A], f func(A) Optional[B]) Optional[B] {
+	if o.present {
+		return f(o.value)
+	}
+	return None[B]()
+}
+
+// Filter returns this optional if the value is present and satisfies the condition, and None otherwise
+func (o Optional[T]) Filter(cond func(T) bool) Optional[T] {
+	if o.present && cond(o.value) {
+		return o
+	}
+	return None[T]()
+}
+
+// Or returns this optional if the value is present, and the other optional otherwise
+func (o Optional[T]) Or(other Optional[T]) Optional[T] {
+	if o.present {
+		return o
+	}
+	return other
+}
+
+// OrGet returns this optional if the value is present, and the result of the given function otherwise
+func (o Optional[T]) OrGet(other func() Optional[T]) Optional[T] {
+	if o.present {
+		return o
+	}
+	return other()
+}
+
+// IfPresent calls the function with the value, if present
+func (o Optional[T]) IfPresent(f func(T)) {
+	if o.present {
+		f(o.value)
+	}
+}
+
+// ToPointer returns the value as a pointer, using nil to represent the absent value.
+// This is the same as GetPointer and the inverse of FromPointer.
+func (o Optional[T]) ToPointer() *T {
+	return o.GetPointer()
+}
+
+// FromPointer creates an Optional from a pointer, where nil is mapped to None
+func FromPointer[T any](p *T) Optional[T] {
+	if p == nil {
+		return None[T]()
+	}
+	return Some(*p)
+}
+
+// FromError creates an Optional from a value and an error, where the value is only present if the error is nil
+func FromError[T any](v T, err error) Optional[T] {
+	return Make(v, err == nil)
+}
+
+// Zip combines two optional values into an optional pair, which is only present if both values are present
+func Zip[A, B any](a Optional[A], b Optional[B]) Optional[hash.Pair[A, B]] {
+	if a.present && b.present {
+		return Some(hash.Pair[A, B]{A: a.value, B: b.value})
+	}
+	return None[hash.Pair[A, B]]()
+}
+
+// Sequence turns an iterable of optional values into an optional list.
+// The result is None if any of the values is absent.
+func Sequence[T any](i iterable.Iterable[Optional[T]]) Optional[list.List[T]] {
+	return Traverse(i, func(o Optional[T]) Optional[T] {
+		return o
+	})
+}
+
+// Traverse applies a function to all elements of the iterable and collects the results in a list.
+// The result is None if the function returns None for any element.
+// The iteration stops at the first element where the function returns None.
+func Traverse[A, B any](i iterable.Iterable[A], f func(A) Optional[B]) Optional[list.List[B]] {
+	n, _ := iterable.KnownLength(i)
+	res := make([]B, 0, n)
+	for it := iterable.Start(i); it.HasNext(); it.Next() {
+		b, ok := f(it.Current()).Get()
+		if !ok {
+			return None[list.List[B]]()
+		}
+		res = append(res, b)
+	}
+	return Some(list.New(res...))
+}
@@ -3,6 +3,7 @@ package opt
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/peterzeller/go-fun/equality"
 	"github.com/peterzeller/go-fun/iterable"
 )
 
@@ -110,6 +111,22 @@ func (o Optional[T]) Iterator() iterable.Iterator[T] {
 	return iterable.Empty[T]().Iterator()
 }
 
+// Equal checks whether this optional value is equal to another optional value.
+// Two empty values are equal and an empty value is not equal to a present value.
+func (o Optional[T]) Equal(other Optional[T], eq equality.Equality[T]) bool {
+	if o.present != other.present {
+		return false
+	}
+	return !o.present || eq.Equal(o.value, other.value)
+}
+
+// Equality returns an equality instance for optional values, given an equality instance for the values.
+func Equality[T any](eq equality.Equality[T]) equality.Equality[Optional[T]] {
+	return equality.Fun[Optional[T]](func(a, b Optional[T]) bool {
+		return a.Equal(b, eq)
+	})
+}
+
 // String representation of the optional value
 func (o Optional[T]) String() string {
 	if o.present {
@@ -3,9 +3,15 @@ package opt_test
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/hash"
 	"github.com/peterzeller/go-fun/iterable"
 	"github.com/peterzeller/go-fun/list/list"
 	"github.com/peterzeller/go-fun/opt"
+	"github.com/stretchr/testify/require"
 )
 
 func ExampleFirst() {
@@ -162,3 +168,69 @@ func ExampleFind() {
 	fmt.Printf("o1 = %v, o2 = %v\n", o1, o2)
 	// output: o1 = Some(5), o2 = None()
 }
+
+func ExampleEquality() {
+	eq := opt.Equality(equality.StringIgnoreCase())
+	fmt.Println(eq.Equal(opt.Some("hello"), opt.Some("HELLO")))
+	fmt.Println(eq.Equal(opt.Some("hello"), opt.None[string]()))
+	fmt.Println(eq.Equal(opt.None[string](), opt.None[string]()))
+	// output:
+	// true
+	// false
+	// true
+}
+
+func ExampleMap() {
+	fmt.Println(opt.Map(opt.Some(21), func(x int) int { return 2 * x }))
+	fmt.Println(opt.Map(opt.None[int](), func(x int) int { return 2 * x }))
+	// output:
+	// Some(42)
+	// None()
+}
+
+func ExampleTraverse() {
+	parse := func(s string) opt.Optional[int] {
+		return opt.FromError(strconv.Atoi(s))
+	}
+	fmt.Println(opt.Traverse(iterable.New("1", "2", "3"), parse))
+	fmt.Println(opt.Traverse(iterable.New("1", "b", "3"), parse))
+	// output:
+	// Some([1, 2, 3])
+	// None()
+}
+
+func TestCombinators(t *testing.T) {
+	half := func(x int) opt.Optional[int] {
+		return opt.Make(x/2, x%2 == 0)
+	}
+	require.Equal(t, opt.Some(21), opt.FlatMap(opt.Some(42), half))
+	require.Equal(t, opt.None[int](), opt.FlatMap(opt.Some(21), half))
+	require.Equal(t, opt.None[int](), opt.FlatMap(opt.None[int](), half))
+
+	positive := func(x int) bool { return x > 0 }
+	require.Equal(t, opt.Some(1), opt.Some(1).Filter(positive))
+	require.Equal(t, opt.None[int](), opt.Some(-1).Filter(positive))
+	require.Equal(t, opt.None[int](), opt.None[int]().Filter(positive))
+
+	require.Equal(t, opt.Some(1), opt.Some(1).Or(opt.Some(2)))
+	require.Equal(t, opt.Some(2), opt.None[int]().Or(opt.Some(2)))
+	require.Equal(t, opt.Some(3), opt.None[int]().OrGet(func() opt.Optional[int] { return opt.Some(3) }))
+
+	sum := 0
+	opt.Some(5).IfPresent(func(x int) { sum += x })
+	opt.None[int]().IfPresent(func(x int) { sum += x })
+	require.Equal(t, 5, sum)
+
+	x := 7
+	require.Equal(t, opt.Some(7), opt.FromPointer(&x))
+	require.Equal(t, opt.None[int](), opt.FromPointer[int](nil))
+	require.Equal(t, 7, *opt.Some(7).ToPointer())
+	require.Nil(t, opt.None[int]().ToPointer())
+
+	require.Equal(t, opt.Some(hash.Pair[int, string]{A: 1, B: "a"}), opt.Zip(opt.Some(1), opt.Some("a")))
+	require.False(t, opt.Zip(opt.Some(1), opt.None[string]()).Present())
+	require.False(t, opt.Zip(opt.None[int](), opt.Some("a")).Present())
+
+	require.Equal(t, opt.Some(list.New(1, 2)), opt.Sequence(iterable.New(opt.Some(1), opt.Some(2))))
+	require.Equal(t, opt.None[list.List[int]](), opt.Sequence(iterable.New(opt.Some(1), opt.None[int]())))
+}
@@ -0,0 +1,7 @@
+/*
+Package pqueue implements an immutable priority queue.
+
+The queue is implemented as a leftist heap, so all operations have a worst-case running time of O(log n)
+and old versions of a heap remain valid after updates.
+*/
+package pqueue
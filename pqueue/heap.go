@@ -0,0 +1,176 @@
+package pqueue
+
+import (
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// Heap is an immutable priority queue.
+// The order of elements is determined by the less function given when creating the heap.
+type Heap[T any] struct {
+	root *node[T]
+	less func(a, b T) bool
+}
+
+type node[T any] struct {
+	elem T
+	// rank is the length of the right spine
+	rank  int
+	size  int
+	left  *node[T]
+	right *node[T]
+}
+
+// New creates a new heap with the given elements.
+// The less function determines the order of the elements: The smallest element is returned first.
+func New[T any](less func(a, b T) bool, elems ...T) Heap[T] {
+	return FromIterable(less, iterable.FromSlice(elems))
+}
+
+// FromIterable creates a new heap from the elements of the iterable in O(n).
+func FromIterable[T any](less func(a, b T) bool, elems iterable.Iterable[T]) Heap[T] {
+	// merge singleton heaps pairwise, which takes linear time in total
+	nodes := make([]*node[T], 0)
+	for it := elems.Iterator(); ; {
+		x, ok := it.Next()
+		if !ok {
+			break
+		}
+		nodes = append(nodes, singleton(x))
+	}
+	for len(nodes) > 1 {
+		merged := make([]*node[T], 0, (len(nodes)+1)/2)
+		for i := 0; i+1 < len(nodes); i += 2 {
+			merged = append(merged, merge(nodes[i], nodes[i+1], less))
+		}
+		if len(nodes)%2 == 1 {
+			merged = append(merged, nodes[len(nodes)-1])
+		}
+		nodes = merged
+	}
+	res := Heap[T]{less: less}
+	if len(nodes) == 1 {
+		res.root = nodes[0]
+	}
+	return res
+}
+
+func singleton[T any](x T) *node[T] {
+	return &node[T]{elem: x, rank: 1, size: 1}
+}
+
+func (n *node[T]) getRank() int {
+	if n == nil {
+		return 0
+	}
+	return n.rank
+}
+
+func (n *node[T]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// merge two leftist heaps.
+// The recursion only follows the right spines, which have logarithmic length.
+func merge[T any](a, b *node[T], less func(a, b T) bool) *node[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if less(b.elem, a.elem) {
+		a, b = b, a
+	}
+	left := a.left
+	right := merge(a.right, b, less)
+	// keep the invariant that the rank of the left child is at least the rank of the right child
+	if left.getRank() < right.getRank() {
+		left, right = right, left
+	}
+	return &node[T]{
+		elem:  a.elem,
+		rank:  right.getRank() + 1,
+		size:  left.getSize() + right.getSize() + 1,
+		left:  left,
+		right: right,
+	}
+}
+
+// Insert an element into the heap
+func (h Heap[T]) Insert(elem T) Heap[T] {
+	return Heap[T]{
+		root: merge(h.root, singleton(elem), h.less),
+		less: h.less,
+	}
+}
+
+// InsertAll inserts all elements of the iterable into the heap
+func (h Heap[T]) InsertAll(elems iterable.Iterable[T]) Heap[T] {
+	return h.Merge(FromIterable(h.less, elems))
+}
+
+// Min returns the smallest element of the heap.
+// Returns false if the heap is empty.
+func (h Heap[T]) Min() (T, bool) {
+	if h.root == nil {
+		return zero.Value[T](), false
+	}
+	return h.root.elem, true
+}
+
+// DeleteMin removes the smallest element from the heap.
+// If the heap is empty, the heap is returned unchanged.
+func (h Heap[T]) DeleteMin() Heap[T] {
+	if h.root == nil {
+		return h
+	}
+	return Heap[T]{
+		root: merge(h.root.left, h.root.right, h.less),
+		less: h.less,
+	}
+}
+
+// PopMin returns the smallest element and the heap without that element.
+// Returns false if the heap is empty.
+func (h Heap[T]) PopMin() (T, Heap[T], bool) {
+	x, ok := h.Min()
+	return x, h.DeleteMin(), ok
+}
+
+// Merge two heaps.
+// The resulting heap uses the order of this heap.
+func (h Heap[T]) Merge(other Heap[T]) Heap[T] {
+	return Heap[T]{
+		root: merge(h.root, other.root, h.less),
+		less: h.less,
+	}
+}
+
+// Size returns the number of elements in the heap
+func (h Heap[T]) Size() int {
+	return h.root.getSize()
+}
+
+// Empty checks whether the heap has no elements
+func (h Heap[T]) Empty() bool {
+	return h.root == nil
+}
+
+// Iterator returns the elements of the heap in priority order, starting with the smallest element.
+// Iterating does not change the heap.
+func (h Heap[T]) Iterator() iterable.Iterator[T] {
+	current := h
+	return iterable.Fun[T](func() (T, bool) {
+		x, rest, ok := current.PopMin()
+		current = rest
+		return x, ok
+	})
+}
+
+func (h Heap[T]) String() string {
+	return iterable.String[T](h)
+}
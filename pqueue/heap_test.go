@@ -0,0 +1,98 @@
+package pqueue_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/pqueue"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+func less(a, b int) bool {
+	return a < b
+}
+
+func ExampleHeap_Insert() {
+	h := pqueue.New(less, 5, 3)
+	h2 := h.Insert(1).Insert(4)
+	fmt.Printf("h = %v\n", h)
+	fmt.Printf("h2 = %v\n", h2)
+	// output:
+	// h = [3, 5]
+	// h2 = [1, 3, 4, 5]
+}
+
+func ExampleHeap_PopMin() {
+	h := pqueue.New(less, 5, 3, 8)
+	x, h, _ := h.PopMin()
+	fmt.Printf("x = %v, rest = %v\n", x, h)
+	// output:
+	// x = 3, rest = [5, 8]
+}
+
+func ExampleHeap_Merge() {
+	h1 := pqueue.New(less, 5, 1)
+	h2 := pqueue.New(less, 4, 2)
+	fmt.Printf("%v\n", h1.Merge(h2))
+	// output: [1, 2, 4, 5]
+}
+
+func TestHeapSort(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		elems := rapid.SliceOf(rapid.IntRange(-20, 20)).Draw(t, "elems").([]int)
+		h := pqueue.New(less)
+		for _, x := range elems {
+			h = h.Insert(x)
+		}
+		sorted := append([]int{}, elems...)
+		sort.Ints(sorted)
+		require.Equal(t, len(elems), h.Size())
+		require.Equal(t, sorted, append([]int{}, iterable.ToSlice[int](h)...))
+		require.Equal(t, sorted, append([]int{}, iterable.ToSlice[int](pqueue.New(less, elems...))...))
+	})
+}
+
+func TestHeapOperations(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		heaps := []pqueue.Heap[int]{pqueue.New(less)}
+		models := [][]int{{}}
+		n := rapid.IntRange(1, 50).Draw(t, "n").(int)
+		for i := 0; i < n; i++ {
+			j := rapid.IntRange(0, len(heaps)-1).Draw(t, "heap").(int)
+			h := heaps[j]
+			m := models[j]
+			newM := []int{}
+			switch rapid.IntRange(0, 2).Draw(t, "cmd").(int) {
+			case 0:
+				x := rapid.IntRange(-20, 20).Draw(t, "x").(int)
+				h = h.Insert(x)
+				newM = append(append([]int{}, m...), x)
+			case 1:
+				h = h.DeleteMin()
+				if len(m) > 0 {
+					newM = append(newM, m[1:]...)
+				}
+			case 2:
+				k := rapid.IntRange(0, len(heaps)-1).Draw(t, "other").(int)
+				h = h.Merge(heaps[k])
+				newM = append(append([]int{}, m...), models[k]...)
+			}
+			sort.Ints(newM)
+			heaps = append(heaps, h)
+			models = append(models, newM)
+			// all versions must remain valid
+			for k := range heaps {
+				require.Equal(t, len(models[k]), heaps[k].Size())
+				min, ok := heaps[k].Min()
+				require.Equal(t, len(models[k]) > 0, ok)
+				if ok {
+					require.Equal(t, models[k][0], min)
+				}
+			}
+			require.Equal(t, newM, append([]int{}, iterable.ToSlice[int](h)...))
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package promise
+
+// Resolved returns a future that is already resolved with the given value.
+func Resolved[T any](value T) Future[T] {
+	p := New[T]()
+	p.Resolve(value)
+	return p.Future()
+}
+
+// Rejected returns a future that is already rejected with the given error.
+func Rejected[T any](err error) Future[T] {
+	p := New[T]()
+	p.Reject(err)
+	return p.Future()
+}
+
+// Map returns a future that applies f to the value of the given future.
+// If the given future is rejected, the resulting future is rejected with the same error.
+// The function f is called on the goroutine that completes the given future.
+func Map[A, B any](fut Future[A], f func(A) B) Future[B] {
+	return Then(fut, func(a A) (B, error) {
+		return f(a), nil
+	})
+}
+
+// Then returns a future that applies f to the value of the given future.
+// The resulting future is rejected if the given future is rejected or if f returns an error.
+// The function f is called on the goroutine that completes the given future.
+func Then[A, B any](fut Future[A], f func(A) (B, error)) Future[B] {
+	p := New[B]()
+	fut.OnComplete(func(a A, err error) {
+		if err != nil {
+			p.Reject(err)
+			return
+		}
+		p.complete(try(func() (B, error) {
+			return f(a)
+		}))
+	})
+	return p.Future()
+}
+
+// FlatMap returns a future that continues with the future returned by f.
+// If the given future is rejected, the resulting future is rejected with the same error.
+func FlatMap[A, B any](fut Future[A], f func(A) Future[B]) Future[B] {
+	p := New[B]()
+	fut.OnComplete(func(a A, err error) {
+		if err != nil {
+			p.Reject(err)
+			return
+		}
+		next, err := try(func() (Future[B], error) {
+			return f(a), nil
+		})
+		if err != nil {
+			p.Reject(err)
+			return
+		}
+		next.OnComplete(func(b B, err error) {
+			p.complete(b, err)
+		})
+	})
+	return p.Future()
+}
+
+// Recover returns a future that replaces an error of the given future with the value returned by f.
+func Recover[T any](fut Future[T], f func(error) T) Future[T] {
+	return RecoverWith(fut, func(err error) Future[T] {
+		return Resolved(f(err))
+	})
+}
+
+// RecoverWith returns a future that continues with the future returned by f when the given future is rejected.
+func RecoverWith[T any](fut Future[T], f func(error) Future[T]) Future[T] {
+	p := New[T]()
+	fut.OnComplete(func(v T, err error) {
+		if err == nil {
+			p.Resolve(v)
+			return
+		}
+		next, fErr := try(func() (Future[T], error) {
+			return f(err), nil
+		})
+		if fErr != nil {
+			p.Reject(fErr)
+			return
+		}
+		next.OnComplete(func(v T, err error) {
+			p.complete(v, err)
+		})
+	})
+	return p.Future()
+}
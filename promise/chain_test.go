@@ -0,0 +1,158 @@
+package promise_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/peterzeller/go-fun/promise"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleMap() {
+	p := promise.New[int]()
+	f := promise.Map(p.Future(), func(x int) string {
+		return fmt.Sprintf("x%d", x)
+	})
+	go func() {
+		p.Resolve(42)
+	}()
+	v, err := f.Wait(context.Background())
+	fmt.Printf("v = %v, err = %v\n", v, err)
+	// output: v = x42, err = <nil>
+}
+
+func ExampleThen() {
+	f := promise.Then(promise.Resolved("12a"), strconv.Atoi)
+	v, err := f.Wait(context.Background())
+	fmt.Printf("v = %v, err = %v\n", v, err)
+	// output: v = 0, err = strconv.Atoi: parsing "12a": invalid syntax
+}
+
+func ExampleFlatMap() {
+	f := promise.FlatMap(promise.Resolved(20), func(x int) promise.Future[int] {
+		return promise.Async(func() int {
+			return x + 22
+		})
+	})
+	v, err := f.Wait(context.Background())
+	fmt.Printf("v = %v, err = %v\n", v, err)
+	// output: v = 42, err = <nil>
+}
+
+func ExampleRecover() {
+	f := promise.Recover(promise.Rejected[int](fmt.Errorf("failed")), func(err error) int {
+		return -1
+	})
+	v, err := f.Wait(context.Background())
+	fmt.Printf("v = %v, err = %v\n", v, err)
+	// output: v = -1, err = <nil>
+}
+
+func ExampleFuture_OnComplete() {
+	p := promise.New[int]()
+	p.Future().OnComplete(func(v int, err error) {
+		fmt.Printf("v = %v, err = %v\n", v, err)
+	})
+	p.Resolve(42)
+	// output: v = 42, err = <nil>
+}
+
+func TestFirstCompletionWins(t *testing.T) {
+	p := promise.New[int]()
+	p.Resolve(1)
+	p.Resolve(2)
+	p.Reject(fmt.Errorf("too late"))
+	v, err := p.Future().Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+}
+
+func TestDone(t *testing.T) {
+	p := promise.New[int]()
+	select {
+	case <-p.Future().Done():
+		t.Fatal("future should not be done yet")
+	default:
+	}
+	p.Resolve(1)
+	<-p.Future().Done()
+}
+
+func TestOnCompleteAfterCompletion(t *testing.T) {
+	f := promise.Rejected[int](fmt.Errorf("failed"))
+	var res error
+	f.OnComplete(func(_ int, err error) {
+		res = err
+	})
+	require.EqualError(t, res, "failed")
+}
+
+func TestOnCompletePanic(t *testing.T) {
+	p := promise.New[int]()
+	var results []int
+	p.Future().OnComplete(func(v int, _ error) {
+		results = append(results, v)
+	})
+	p.Future().OnComplete(func(int, error) {
+		panic("boom")
+	})
+	p.Future().OnComplete(func(v int, _ error) {
+		results = append(results, v+1)
+	})
+	require.NotPanics(t, func() { p.Resolve(42) })
+	require.Equal(t, []int{42, 43}, results)
+	require.NotPanics(t, func() {
+		p.Future().OnComplete(func(int, error) {
+			panic("boom")
+		})
+	})
+}
+
+func TestMapPanic(t *testing.T) {
+	f := promise.Map(promise.Resolved(0), func(x int) int {
+		return 1 / x
+	})
+	_, err := f.Wait(context.Background())
+	require.EqualError(t, err, "runtime error: integer divide by zero")
+}
+
+func TestMapRejected(t *testing.T) {
+	called := false
+	f := promise.Map(promise.Rejected[int](fmt.Errorf("failed")), func(x int) int {
+		called = true
+		return x
+	})
+	_, err := f.Wait(context.Background())
+	require.EqualError(t, err, "failed")
+	require.False(t, called)
+}
+
+func TestFlatMapRejected(t *testing.T) {
+	f := promise.FlatMap(promise.Resolved(1), func(x int) promise.Future[int] {
+		return promise.Rejected[int](fmt.Errorf("inner %d", x))
+	})
+	_, err := f.Wait(context.Background())
+	require.EqualError(t, err, "inner 1")
+}
+
+func TestRecoverWith(t *testing.T) {
+	p := promise.New[int]()
+	f := promise.RecoverWith(p.Future(), func(err error) promise.Future[int] {
+		return promise.Async(func() int { return 7 })
+	})
+	p.Reject(fmt.Errorf("failed"))
+	v, err := f.Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 7, v)
+}
+
+func TestRecoverNotCalledOnSuccess(t *testing.T) {
+	f := promise.Recover(promise.Resolved(3), func(err error) int {
+		panic("should not be called")
+	})
+	v, err := f.Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, v)
+}
@@ -0,0 +1,46 @@
+package promise
+
+import "time"
+
+// Clock provides the current time and timers.
+// It is used by the time-based functions in this package, like Delay, WithTimeout and Retry.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// AfterFunc calls f in its own goroutine after the duration d has elapsed
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a timer created by a Clock
+type Timer interface {
+	// Stop prevents the timer from firing.
+	// Returns false if the timer has already fired or was stopped before.
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// RealClock returns the clock based on the time package.
+func RealClock() Clock {
+	return realClock{}
+}
+
+// SetClock replaces the clock used by the time-based functions in this package.
+// It returns a function that can be called to reset the clock.
+func SetClock(c Clock) (reset func()) {
+	prev := clock
+	clock = c
+	return func() {
+		clock = prev
+	}
+}
+
+var clock = RealClock()
@@ -0,0 +1,99 @@
+package promise
+
+import (
+	"context"
+
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// AsyncOpts are options for starting asynchronous work with AsyncCtxOpts.
+type AsyncOpts struct {
+	// CancelWhenUnawaited cancels the work when it is no longer awaited.
+	// This happens when the last call to Future.Wait returns because its context is done before the future is completed.
+	// Callbacks registered with Future.OnComplete (including Map, Then, etc.) count as waiters that never give up.
+	CancelWhenUnawaited bool
+	// Executor used to run the work (uses the default executor if nil)
+	Executor Executor
+}
+
+// AsyncCtx runs a function asynchronously and returns a future with the result or the error returned by the function.
+// The function is called with a context that is cancelled when ctx is cancelled or when the future is cancelled
+// using Future.Cancel.
+func AsyncCtx[T any](ctx context.Context, f func(ctx context.Context) (T, error)) Future[T] {
+	return AsyncCtxOpts(ctx, AsyncOpts{}, f)
+}
+
+// AsyncCtxVoid is like AsyncCtx for functions without a result.
+func AsyncCtxVoid(ctx context.Context, f func(ctx context.Context) error) Future[struct{}] {
+	return AsyncCtx(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, f(ctx)
+	})
+}
+
+// AsyncCtxOpts is like AsyncCtx with additional options.
+func AsyncCtxOpts[T any](ctx context.Context, opts AsyncOpts, f func(ctx context.Context) (T, error)) Future[T] {
+	p := New[T]()
+	workCtx, cancel := context.WithCancel(ctx)
+	p.data.cancelWork = cancel
+	p.data.cancelWhenUnawaited = opts.CancelWhenUnawaited
+	e := opts.Executor
+	if e == nil {
+		e = defaultExecutor
+	}
+	err := submit(e, p, func() (T, error) {
+		defer cancel()
+		return f(workCtx)
+	})
+	if err != nil {
+		cancel()
+	}
+	return p.Future()
+}
+
+// Cancel rejects the future with context.Canceled and cancels the context of the asynchronous work computing it.
+// Cancel has no effect if the future is already completed.
+func (f Future[T]) Cancel() {
+	p := Promise[T]{data: f.data}
+	if p.complete(zero.Value[T](), context.Canceled) && f.data.cancelWork != nil {
+		f.data.cancelWork()
+	}
+}
+
+// acquire registers a waiter
+func (d *pData[T]) acquire() {
+	d.mutex.Lock()
+	d.waiters++
+	d.mutex.Unlock()
+}
+
+// release unregisters a waiter and cancels the future if it is no longer awaited
+func (d *pData[T]) release() {
+	d.mutex.Lock()
+	d.waiters--
+	cancel := d.cancelWhenUnawaited && d.waiters == 0 && !d.completed
+	d.mutex.Unlock()
+	if cancel {
+		Future[T]{data: d}.Cancel()
+	}
+}
+
+// afterDone calls f in its own goroutine once ctx is done, similar to context.AfterFunc.
+// Calling stop ends the goroutine; it must be called at most once.
+func afterDone(ctx context.Context, f func()) (stop func()) {
+	done := ctx.Done()
+	if done == nil {
+		// ctx is never done
+		return func() {}
+	}
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-done:
+			f()
+		case <-stopped:
+		}
+	}()
+	return func() {
+		close(stopped)
+	}
+}
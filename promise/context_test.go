@@ -0,0 +1,109 @@
+package promise_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/peterzeller/go-fun/promise"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleAsyncCtx() {
+	ctx, cancel := context.WithCancel(context.Background())
+	f := promise.AsyncCtx(ctx, func(ctx context.Context) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	cancel()
+	v, err := f.Wait(context.Background())
+	fmt.Printf("v = %v, err = %v\n", v, err)
+	// output: v = 0, err = context canceled
+}
+
+func ExampleFuture_Cancel() {
+	workDone := make(chan error)
+	f := promise.AsyncCtxVoid(context.Background(), func(ctx context.Context) error {
+		<-ctx.Done()
+		workDone <- ctx.Err()
+		return nil
+	})
+	f.Cancel()
+	_, err := f.Wait(context.Background())
+	fmt.Printf("err = %v\n", err)
+	fmt.Printf("work: %v\n", <-workDone)
+	// output:
+	// err = context canceled
+	// work: context canceled
+}
+
+func TestCancelCompleted(t *testing.T) {
+	f := promise.AsyncCtx(context.Background(), func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+	_, err := f.Wait(context.Background())
+	require.NoError(t, err)
+	f.Cancel()
+	v, err := f.Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 42, v)
+}
+
+func TestCancelWhenUnawaited(t *testing.T) {
+	// waiting signals that a call to Future.Wait has registered itself as a waiter
+	waiting := make(chan struct{}, 2)
+	defer promise.SetupWaitMock(func(ctxA, ctxB context.Context) bool {
+		waiting <- struct{}{}
+		select {
+		case <-ctxA.Done():
+			return true
+		case <-ctxB.Done():
+			return false
+		}
+	})()
+
+	workDone := make(chan error, 1)
+	f := promise.AsyncCtxOpts(context.Background(), promise.AsyncOpts{CancelWhenUnawaited: true},
+		func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			workDone <- ctx.Err()
+			return 0, ctx.Err()
+		})
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	wait1 := promise.AsyncErr(func() (int, error) { return f.Wait(ctx1) })
+	wait2 := promise.AsyncErr(func() (int, error) { return f.Wait(ctx2) })
+	<-waiting
+	<-waiting
+
+	cancel1()
+	_, err := wait1.Wait(context.Background())
+	require.ErrorIs(t, err, context.Canceled)
+	// the first waiter was released before wait1 completed, so the future would already be cancelled
+	select {
+	case <-f.Done():
+		t.Fatal("future should not be cancelled while awaited")
+	default:
+	}
+
+	cancel2()
+	_, err = wait2.Wait(context.Background())
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorIs(t, <-workDone, context.Canceled)
+}
+
+func TestNotCancelledWithoutOption(t *testing.T) {
+	p := promise.New[int]()
+	f := promise.AsyncCtx(context.Background(), func(ctx context.Context) (int, error) {
+		return p.Future().Wait(ctx)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := f.Wait(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	p.Resolve(42)
+	v, err := f.Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 42, v)
+}
@@ -0,0 +1,59 @@
+package promise
+
+import (
+	"context"
+	"fmt"
+)
+
+// Executor runs tasks asynchronously.
+type Executor interface {
+	// Execute schedules a task for asynchronous execution.
+	// It returns an error if the task is rejected and will not be executed.
+	Execute(task func()) error
+}
+
+// ExecutorFunc transforms a function into an Executor instance
+type ExecutorFunc func(task func()) error
+
+func (f ExecutorFunc) Execute(task func()) error {
+	return f(task)
+}
+
+// ErrRejected is returned by executors that reject a task because they are overloaded.
+var ErrRejected = fmt.Errorf("task rejected by executor")
+
+// ErrShutdown is returned by executors that reject a task because they are shut down.
+var ErrShutdown = fmt.Errorf("executor is shut down")
+
+// Goroutines returns an executor that starts a new goroutine for each task.
+func Goroutines() Executor {
+	return ExecutorFunc(func(task func()) error {
+		go task()
+		return nil
+	})
+}
+
+// Submit runs a function on the given executor and returns a future with the result or the error returned by the function.
+// If the executor rejects the function, the future is rejected with the error returned by the executor.
+func Submit[T any](e Executor, f func() (T, error)) Future[T] {
+	p := New[T]()
+	submit(e, p, f)
+	return p.Future()
+}
+
+// SubmitCtx is like Submit, but the function is called with a context like in AsyncCtx.
+func SubmitCtx[T any](ctx context.Context, e Executor, f func(ctx context.Context) (T, error)) Future[T] {
+	return AsyncCtxOpts(ctx, AsyncOpts{Executor: e}, f)
+}
+
+// submit runs f on the executor and completes p with the result.
+// If the executor rejects the task, p is rejected and the error is returned.
+func submit[T any](e Executor, p Promise[T], f func() (T, error)) error {
+	err := e.Execute(func() {
+		p.complete(try(f))
+	})
+	if err != nil {
+		p.Reject(err)
+	}
+	return err
+}
@@ -2,20 +2,42 @@ package promise
 
 import "context"
 
+// SetDefaultExecutor replaces the executor used by Async, AsyncErr, AsyncCtx and the other functions in this package
+// that start asynchronous work without an explicit executor.
+// It returns a function that can be called to reset the default executor.
+func SetDefaultExecutor(e Executor) (reset func()) {
+	prev := defaultExecutor
+	defaultExecutor = e
+	return func() {
+		defaultExecutor = prev
+	}
+}
+
 // SetupMocks replaces the functions in this file with mocks or fake functions.
 // it returns a function that can be called to reset the functions to their default value.
+//
+// Deprecated: use SetDefaultExecutor to replace the goroutine starter and SetupWaitMock to replace the context waiter.
 func SetupMocks(startGoroutineFunc func(f func()), waitForContextsFunc func(ctxA context.Context, ctxB context.Context) bool) (cancel func()) {
-	startGoroutine = startGoroutineFunc
-	waitForContexts = waitForContextsFunc
+	resetExecutor := SetDefaultExecutor(ExecutorFunc(func(task func()) error {
+		startGoroutineFunc(task)
+		return nil
+	}))
+	resetWait := SetupWaitMock(waitForContextsFunc)
 	return func() {
-		startGoroutine = startGoroutineDefault
-		waitForContexts = waitForContextsDefault
+		resetExecutor()
+		resetWait()
 	}
 }
 
-// startGoroutineDefault starts a new goroutine
-func startGoroutineDefault(f func()) {
-	go f()
+// SetupWaitMock replaces the function used by Future.Wait to wait for either the future or the context to be done.
+// The function must return true if ctxA finishes first and false otherwise.
+// It returns a function that can be called to reset the function to its default value.
+func SetupWaitMock(waitForContextsFunc func(ctxA context.Context, ctxB context.Context) bool) (reset func()) {
+	prev := waitForContexts
+	waitForContexts = waitForContextsFunc
+	return func() {
+		waitForContexts = prev
+	}
 }
 
 // waitForContextsDefault for either context A or context B to finish.
@@ -29,5 +51,5 @@ func waitForContextsDefault(ctxA, ctxB context.Context) bool {
 	}
 }
 
-var startGoroutine = startGoroutineDefault
+var defaultExecutor = Goroutines()
 var waitForContexts = waitForContextsDefault
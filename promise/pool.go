@@ -0,0 +1,132 @@
+package promise
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// RejectionPolicy determines what a Pool does with a task when all workers are busy and the queue is full.
+type RejectionPolicy int
+
+const (
+	// RejectAbort rejects the task with ErrRejected.
+	RejectAbort RejectionPolicy = iota
+	// RejectCallerRuns runs the task synchronously on the goroutine calling Execute.
+	RejectCallerRuns
+	// RejectBlock blocks the caller of Execute until the task can be queued or the pool is shut down.
+	RejectBlock
+)
+
+// PoolOpts configures a Pool.
+type PoolOpts struct {
+	// Workers is the number of goroutines executing tasks (defaults to runtime.NumCPU() if not positive).
+	Workers int
+	// QueueSize is the number of tasks that can wait for a free worker.
+	// With a queue size of 0, tasks are only accepted if a worker is idle.
+	QueueSize int
+	// Rejection determines what happens with tasks that cannot be queued.
+	Rejection RejectionPolicy
+}
+
+// Pool is an Executor that runs tasks on a bounded number of worker goroutines.
+type Pool struct {
+	tasks     chan func()
+	rejection RejectionPolicy
+	// mutex protects shutdown and sending to tasks
+	mutex    sync.RWMutex
+	shutdown bool
+	// closing is closed when Shutdown is called, to unblock callers waiting for space in the queue
+	closing   chan struct{}
+	closeOnce sync.Once
+	// done is closed when all workers have finished
+	done chan struct{}
+}
+
+var _ Executor = &Pool{}
+
+// NewPool creates a new pool and starts its workers.
+// The pool must be shut down with Shutdown to release the workers.
+func NewPool(opts PoolOpts) *Pool {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	queueSize := opts.QueueSize
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	p := &Pool{
+		tasks:     make(chan func(), queueSize),
+		rejection: opts.Rejection,
+		closing:   make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for task := range p.tasks {
+				task()
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(p.done)
+	}()
+	return p
+}
+
+// Execute queues a task for execution by one of the workers.
+// If no worker is idle and the queue is full, the rejection policy of the pool determines what happens.
+// Returns ErrShutdown after the pool is shut down.
+func (p *Pool) Execute(task func()) error {
+	p.mutex.RLock()
+	if p.shutdown {
+		p.mutex.RUnlock()
+		return ErrShutdown
+	}
+	select {
+	case p.tasks <- task:
+		p.mutex.RUnlock()
+		return nil
+	default:
+	}
+	switch p.rejection {
+	case RejectCallerRuns:
+		p.mutex.RUnlock()
+		task()
+		return nil
+	case RejectBlock:
+		defer p.mutex.RUnlock()
+		select {
+		case p.tasks <- task:
+			return nil
+		case <-p.closing:
+			return ErrShutdown
+		}
+	default:
+		p.mutex.RUnlock()
+		return ErrRejected
+	}
+}
+
+// Shutdown stops accepting new tasks and waits until all queued tasks have been executed.
+// If ctx is done before that, Shutdown returns the error of the context, while the workers continue with the remaining tasks.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+		p.mutex.Lock()
+		p.shutdown = true
+		close(p.tasks)
+		p.mutex.Unlock()
+	})
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,136 @@
+package promise_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/peterzeller/go-fun/promise"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleSubmit() {
+	pool := promise.NewPool(promise.PoolOpts{Workers: 2, QueueSize: 10})
+	f := promise.Submit(pool, func() (int, error) {
+		return 42, nil
+	})
+	v, err := f.Wait(context.Background())
+	fmt.Printf("v = %v, err = %v\n", v, err)
+	err = pool.Shutdown(context.Background())
+	fmt.Printf("shutdown: %v\n", err)
+	// output:
+	// v = 42, err = <nil>
+	// shutdown: <nil>
+}
+
+func TestPoolBoundsConcurrency(t *testing.T) {
+	pool := promise.NewPool(promise.PoolOpts{Workers: 3, Rejection: promise.RejectBlock})
+	var running, maxRunning int32
+	var futures []promise.Future[int]
+	for i := 0; i < 50; i++ {
+		i := i
+		futures = append(futures, promise.Submit(pool, func() (int, error) {
+			r := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if r <= m || atomic.CompareAndSwapInt32(&maxRunning, m, r) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return i, nil
+		}))
+	}
+	for i, f := range futures {
+		v, err := f.Wait(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, i, v)
+	}
+	require.NoError(t, pool.Shutdown(context.Background()))
+	require.LessOrEqual(t, maxRunning, int32(3))
+}
+
+func TestPoolRejectAbort(t *testing.T) {
+	pool := promise.NewPool(promise.PoolOpts{Workers: 1, QueueSize: 1, Rejection: promise.RejectAbort})
+	block := make(chan struct{})
+	started := make(chan struct{})
+	f1 := promise.Submit(pool, func() (int, error) {
+		close(started)
+		<-block
+		return 1, nil
+	})
+	<-started
+	f2 := promise.Submit(pool, func() (int, error) { return 2, nil })
+	f3 := promise.Submit(pool, func() (int, error) { return 3, nil })
+	_, err := f3.Wait(context.Background())
+	require.ErrorIs(t, err, promise.ErrRejected)
+	close(block)
+	v, err := f1.Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, v)
+	v, err = f2.Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, v)
+	require.NoError(t, pool.Shutdown(context.Background()))
+}
+
+func TestPoolRejectCallerRuns(t *testing.T) {
+	pool := promise.NewPool(promise.PoolOpts{Workers: 1, QueueSize: 1, Rejection: promise.RejectCallerRuns})
+	block := make(chan struct{})
+	started := make(chan struct{})
+	promise.Submit(pool, func() (int, error) {
+		close(started)
+		<-block
+		return 1, nil
+	})
+	<-started
+	promise.Submit(pool, func() (int, error) { return 2, nil })
+	// runs synchronously, since the only worker is busy and the queue is full
+	f := promise.Submit(pool, func() (int, error) { return 3, nil })
+	select {
+	case <-f.Done():
+	default:
+		t.Fatal("task should have been executed by the caller")
+	}
+	close(block)
+	require.NoError(t, pool.Shutdown(context.Background()))
+}
+
+func TestPoolShutdown(t *testing.T) {
+	pool := promise.NewPool(promise.PoolOpts{Workers: 1, QueueSize: 5})
+	var count int32
+	for i := 0; i < 5; i++ {
+		require.NoError(t, pool.Execute(func() {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&count, 1)
+		}))
+	}
+	require.NoError(t, pool.Shutdown(context.Background()))
+	require.Equal(t, int32(5), atomic.LoadInt32(&count))
+	require.ErrorIs(t, pool.Execute(func() {}), promise.ErrShutdown)
+	_, err := promise.Submit(pool, func() (int, error) { return 0, nil }).Wait(context.Background())
+	require.ErrorIs(t, err, promise.ErrShutdown)
+}
+
+func TestPoolShutdownTimeout(t *testing.T) {
+	pool := promise.NewPool(promise.PoolOpts{Workers: 1, QueueSize: 1})
+	block := make(chan struct{})
+	require.NoError(t, pool.Execute(func() { <-block }))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, pool.Shutdown(ctx), context.DeadlineExceeded)
+	close(block)
+	require.NoError(t, pool.Shutdown(context.Background()))
+}
+
+func TestSetDefaultExecutor(t *testing.T) {
+	pool := promise.NewPool(promise.PoolOpts{Workers: 1})
+	reset := promise.SetDefaultExecutor(pool)
+	defer reset()
+	require.NoError(t, pool.Shutdown(context.Background()))
+	_, err := promise.Async(func() int { return 1 }).Wait(context.Background())
+	require.ErrorIs(t, err, promise.ErrShutdown)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/peterzeller/go-fun/zero"
+	"sync"
 	"sync/atomic"
 )
 
@@ -21,6 +22,16 @@ type pData[T any] struct {
 	cancelFunc func()
 	err        atomic.Pointer[error]
 	value      atomic.Pointer[T]
+	// mutex protects completed, callbacks and waiters
+	mutex     sync.Mutex
+	completed bool
+	// callbacks to run once the promise is resolved or rejected
+	callbacks []func()
+	// cancelWork cancels the context of the asynchronous work computing the result (nil if there is none)
+	cancelWork context.CancelFunc
+	// if cancelWhenUnawaited is set, the work is cancelled when the number of waiters drops to zero
+	cancelWhenUnawaited bool
+	waiters             int
 }
 
 // New promise that can be resolved or rejected.
@@ -34,47 +45,30 @@ func New[T any]() Promise[T] {
 
 // Async runs a function asynchronously and returns a future with the result returned by the function.
 func Async[T any](f func() T) Future[T] {
-	p := New[T]()
-	startGoroutine(func() {
-		defer func() {
-			r := recover()
-			if r != nil {
-				switch err := r.(type) {
-				case error:
-					p.Reject(err)
-				default:
-					p.Reject(fmt.Errorf("%v", r))
-				}
-			}
-		}()
-		p.Resolve(f())
+	return AsyncErr(func() (T, error) {
+		return f(), nil
 	})
-	return p.Future()
 }
 
 // AsyncErr runs a function asynchronously and returns a future with the result or the error returned by the function.
 func AsyncErr[T any](f func() (T, error)) Future[T] {
-	p := New[T]()
-	startGoroutine(func() {
-		defer func() {
-			r := recover()
-			if r != nil {
-				switch err := r.(type) {
-				case error:
-					p.Reject(err)
-				default:
-					p.Reject(fmt.Errorf("%v", r))
-				}
+	return Submit(defaultExecutor, f)
+}
+
+// try calls f and turns a panic into an error.
+func try[T any](f func() (T, error)) (res T, err error) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			switch e := r.(type) {
+			case error:
+				err = e
+			default:
+				err = fmt.Errorf("%v", r)
 			}
-		}()
-		t, err := f()
-		if err == nil {
-			p.Resolve(t)
-		} else {
-			p.Reject(err)
 		}
-	})
-	return p.Future()
+	}()
+	return f()
 }
 
 // AsyncVoid runs a function asynchronously and returns a future with no result
@@ -85,14 +79,41 @@ func AsyncVoid(f func()) Future[struct{}] {
 	})
 }
 
+// Resolve the promise with a value.
+// Only the first call to Resolve or Reject has an effect.
 func (p Promise[T]) Resolve(value T) {
-	p.data.value.Store(&value)
-	p.data.cancelFunc()
+	p.complete(value, nil)
 }
 
+// Reject the promise with an error.
+// Only the first call to Resolve or Reject has an effect.
 func (p Promise[T]) Reject(err error) {
-	p.data.err.Store(&err)
-	p.data.cancelFunc()
+	p.complete(zero.Value[T](), err)
+}
+
+// complete resolves the promise if err is nil and rejects it otherwise.
+// Returns false if the promise was already completed before.
+func (p Promise[T]) complete(value T, err error) bool {
+	d := p.data
+	d.mutex.Lock()
+	if d.completed {
+		d.mutex.Unlock()
+		return false
+	}
+	d.completed = true
+	if err != nil {
+		d.err.Store(&err)
+	} else {
+		d.value.Store(&value)
+	}
+	callbacks := d.callbacks
+	d.callbacks = nil
+	d.mutex.Unlock()
+	d.cancelFunc()
+	for _, c := range callbacks {
+		c()
+	}
+	return true
 }
 
 // Future bound to this promise
@@ -102,14 +123,55 @@ func (p Promise[T]) Future() Future[T] {
 	}
 }
 
+// Wait blocks until the future is completed or the context is done.
 func (f Future[T]) Wait(ctx context.Context) (T, error) {
+	f.data.acquire()
+	defer f.data.release()
 	if waitForContexts(f.data.ctx, ctx) {
-		err := f.data.err.Load()
-		if err != nil {
-			return zero.Value[T](), *err
-		}
-		return *f.data.value.Load(), nil
+		return f.data.result()
 	} else {
 		return zero.Value[T](), ctx.Err()
 	}
 }
+
+// Done returns a channel that is closed when the future is completed.
+func (f Future[T]) Done() <-chan struct{} {
+	return f.data.ctx.Done()
+}
+
+// OnComplete registers a callback that is called with the result of the future once it is completed.
+// If the future is already completed, the callback is called immediately.
+// Otherwise, it is called on the goroutine that completes the future, so callbacks should not block.
+// A panic in the callback is recovered and ignored, so that it neither crashes the completing goroutine
+// nor prevents the other callbacks from running.
+func (f Future[T]) OnComplete(callback func(T, error)) {
+	d := f.data
+	run := func() {
+		defer recoverCallback()
+		callback(d.result())
+	}
+	d.mutex.Lock()
+	if !d.completed {
+		// callbacks count as waiters that never give up
+		d.waiters++
+		d.callbacks = append(d.callbacks, run)
+		d.mutex.Unlock()
+		return
+	}
+	d.mutex.Unlock()
+	run()
+}
+
+// recoverCallback must be deferred by callbacks to stop a panic from propagating
+func recoverCallback() {
+	_ = recover()
+}
+
+// result of a completed promise
+func (d *pData[T]) result() (T, error) {
+	err := d.err.Load()
+	if err != nil {
+		return zero.Value[T](), *err
+	}
+	return *d.value.Load(), nil
+}
@@ -0,0 +1,10 @@
+/*
+Package promisetest provides a deterministic scheduler for testing code that uses the promise package.
+
+The Scheduler replaces the goroutines started by promise.Async and similar functions with tasks in a queue,
+which are all executed on a single goroutine.
+Tasks are picked in an order determined by a seed or a custom strategy, so that a failing interleaving can be
+reproduced by running the test with the same seed again.
+Timers use a virtual clock that only advances when there are no more runnable tasks, so tests never sleep.
+*/
+package promisetest
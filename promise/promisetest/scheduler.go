@@ -0,0 +1,254 @@
+package promisetest
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/peterzeller/go-fun/promise"
+)
+
+// ErrDeadlock is the panic value used when Future.Wait is called, but there are neither runnable tasks nor pending timers.
+var ErrDeadlock = fmt.Errorf("deadlock: waiting for a future, but there are no runnable tasks or timers")
+
+// Scheduler is a deterministic Executor that runs all tasks on the goroutine calling Step, Run or Future.Wait.
+type Scheduler struct {
+	// choose picks the index of the next task to run among the n runnable tasks
+	choose func(n int) int
+	// mutex protects the fields below, so that tasks can also be submitted from other goroutines
+	mutex  sync.Mutex
+	tasks  []func()
+	now    time.Time
+	timers []*Timer
+	seq    int
+	steps  int
+}
+
+var _ promise.Executor = &Scheduler{}
+var _ promise.Clock = &Scheduler{}
+
+// Epoch is the virtual time at which every Scheduler starts.
+var Epoch = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// New creates a scheduler that picks the next task pseudo-randomly using the given seed.
+func New(seed int64) *Scheduler {
+	return NewControlled(rand.New(rand.NewSource(seed)).Intn)
+}
+
+// NewFIFO creates a scheduler that runs tasks in the order in which they were submitted.
+func NewFIFO() *Scheduler {
+	return NewControlled(func(n int) int { return 0 })
+}
+
+// NewControlled creates a scheduler that uses the given function to pick the next task.
+// The function is called with the number of runnable tasks n and must return an index in [0, n).
+// Tasks are indexed in the order in which they were submitted.
+func NewControlled(choose func(n int) int) *Scheduler {
+	return &Scheduler{
+		choose: choose,
+		now:    Epoch,
+	}
+}
+
+// Install makes the scheduler the default executor and clock of the promise package and makes Future.Wait run
+// scheduled tasks until the future is completed.
+// It returns a function that restores the previous behavior.
+func (s *Scheduler) Install() (reset func()) {
+	resetExecutor := promise.SetDefaultExecutor(s)
+	resetWait := promise.SetupWaitMock(s.waitForContexts)
+	resetClock := promise.SetClock(s)
+	return func() {
+		resetClock()
+		resetWait()
+		resetExecutor()
+	}
+}
+
+// Execute adds a task to the queue of runnable tasks.
+func (s *Scheduler) Execute(task func()) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.tasks = append(s.tasks, task)
+	return nil
+}
+
+// Pending returns the number of runnable tasks.
+func (s *Scheduler) Pending() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.tasks)
+}
+
+// Steps returns the number of tasks that have been executed so far.
+func (s *Scheduler) Steps() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.steps
+}
+
+// Step runs one runnable task.
+// Returns false if there was no runnable task.
+func (s *Scheduler) Step() bool {
+	s.mutex.Lock()
+	n := len(s.tasks)
+	if n == 0 {
+		s.mutex.Unlock()
+		return false
+	}
+	i := s.choose(n)
+	if i < 0 || i >= n {
+		s.mutex.Unlock()
+		panic(fmt.Errorf("scheduler strategy chose task %d, but there are only %d tasks", i, n))
+	}
+	task := s.tasks[i]
+	s.tasks = append(s.tasks[:i:i], s.tasks[i+1:]...)
+	s.steps++
+	s.mutex.Unlock()
+	task()
+	return true
+}
+
+// RunUntilIdle runs tasks until there are no more runnable tasks, without advancing the virtual time.
+// Returns the number of tasks executed.
+func (s *Scheduler) RunUntilIdle() int {
+	count := 0
+	for s.Step() {
+		count++
+	}
+	return count
+}
+
+// Run executes tasks until there are no runnable tasks and no pending timers left.
+// When there are no runnable tasks, the virtual time is advanced to the next timer.
+func (s *Scheduler) Run() {
+	for s.Step() || s.fireNextTimer() {
+	}
+}
+
+// Now returns the current virtual time.
+func (s *Scheduler) Now() time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.now
+}
+
+// Advance moves the virtual time forward by d, runs tasks and fires all timers that are due in between.
+func (s *Scheduler) Advance(d time.Duration) {
+	s.mutex.Lock()
+	target := s.now.Add(d)
+	s.mutex.Unlock()
+	for {
+		s.RunUntilIdle()
+		s.mutex.Lock()
+		t := s.nextTimer()
+		if t == nil || t.when.After(target) {
+			s.now = target
+			s.mutex.Unlock()
+			return
+		}
+		s.mutex.Unlock()
+		s.fireNextTimer()
+	}
+}
+
+// Timer is a timer on the virtual clock of a Scheduler.
+type Timer struct {
+	s    *Scheduler
+	when time.Time
+	seq  int
+	f    func()
+}
+
+// AfterFunc schedules f to be submitted as a task once the virtual time has advanced by d.
+func (s *Scheduler) AfterFunc(d time.Duration, f func()) promise.Timer {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.seq++
+	t := &Timer{
+		s:    s,
+		when: s.now.Add(d),
+		seq:  s.seq,
+		f:    f,
+	}
+	s.timers = append(s.timers, t)
+	return t
+}
+
+// Stop prevents the timer from firing.
+// Returns false if the timer has already fired or was stopped before.
+func (t *Timer) Stop() bool {
+	s := t.s
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for i, other := range s.timers {
+		if other == t {
+			s.timers = append(s.timers[:i:i], s.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// nextTimer returns the timer that fires first (or nil if there is none).
+// Must be called while holding the mutex.
+func (s *Scheduler) nextTimer() *Timer {
+	var next *Timer
+	for _, t := range s.timers {
+		if next == nil || t.when.Before(next.when) || t.when.Equal(next.when) && t.seq < next.seq {
+			next = t
+		}
+	}
+	return next
+}
+
+// fireNextTimer advances the virtual time to the next timer and submits its function as a task.
+// Returns false if there are no pending timers.
+func (s *Scheduler) fireNextTimer() bool {
+	s.mutex.Lock()
+	t := s.nextTimer()
+	if t == nil {
+		s.mutex.Unlock()
+		return false
+	}
+	if t.when.After(s.now) {
+		s.now = t.when
+	}
+	s.mutex.Unlock()
+	if t.Stop() {
+		_ = s.Execute(t.f)
+	}
+	return true
+}
+
+// waitForContexts runs tasks and timers until one of the contexts is done.
+func (s *Scheduler) waitForContexts(ctxA, ctxB context.Context) bool {
+	for {
+		if ctxA.Err() != nil {
+			return true
+		}
+		if ctxB.Err() != nil {
+			return false
+		}
+		if !s.Step() && !s.fireNextTimer() {
+			panic(ErrDeadlock)
+		}
+	}
+}
+
+// Explore runs the test function once for each seed in [0, n) as a subtest.
+// Each run uses a new Scheduler created with New(seed), which is installed for the duration of the run.
+// The name of a failing subtest contains the seed, so that the failing interleaving can be reproduced.
+func Explore(t *testing.T, n int, test func(t *testing.T, s *Scheduler)) {
+	for seed := int64(0); seed < int64(n); seed++ {
+		seed := seed
+		t.Run(fmt.Sprintf("seed=%d", seed), func(t *testing.T) {
+			s := New(seed)
+			reset := s.Install()
+			defer reset()
+			test(t, s)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package promisetest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/peterzeller/go-fun/promise"
+	"github.com/peterzeller/go-fun/promise/promisetest"
+	"github.com/stretchr/testify/require"
+)
+
+// incrementTwice increments a counter from two tasks, using an unsafe read-modify-write with a Wait in between.
+func incrementTwice() int {
+	counter := 0
+	incr := func() int {
+		v := counter
+		// waiting allows other tasks to run
+		_, _ = promise.Async(func() int { return 0 }).Wait(context.Background())
+		counter = v + 1
+		return counter
+	}
+	a := promise.Async(incr)
+	b := promise.Async(incr)
+	_, _ = a.Wait(context.Background())
+	_, _ = b.Wait(context.Background())
+	return counter
+}
+
+func ExampleNew() {
+	for _, seed := range []int64{0, 1} {
+		s := promisetest.New(seed)
+		reset := s.Install()
+		fmt.Printf("seed %d: counter = %d\n", seed, incrementTwice())
+		reset()
+	}
+	// output:
+	// seed 0: counter = 1
+	// seed 1: counter = 2
+}
+
+func TestExploreFindsLostUpdate(t *testing.T) {
+	results := make(map[int]bool)
+	promisetest.Explore(t, 20, func(t *testing.T, s *promisetest.Scheduler) {
+		results[incrementTwice()] = true
+	})
+	require.Equal(t, map[int]bool{1: true, 2: true}, results)
+}
+
+func TestSameSeedSameResult(t *testing.T) {
+	run := func(seed int64) []int {
+		s := promisetest.New(seed)
+		reset := s.Install()
+		defer reset()
+		var order []int
+		for i := 0; i < 10; i++ {
+			i := i
+			promise.AsyncVoid(func() { order = append(order, i) })
+		}
+		s.Run()
+		return order
+	}
+	for seed := int64(0); seed < 10; seed++ {
+		require.Equal(t, run(seed), run(seed))
+	}
+}
+
+func TestFIFO(t *testing.T) {
+	s := promisetest.NewFIFO()
+	reset := s.Install()
+	defer reset()
+	var order []int
+	for i := 0; i < 5; i++ {
+		i := i
+		promise.AsyncVoid(func() { order = append(order, i) })
+	}
+	require.Equal(t, 5, s.Pending())
+	require.Equal(t, 5, s.RunUntilIdle())
+	require.Equal(t, []int{0, 1, 2, 3, 4}, order)
+	require.Equal(t, 5, s.Steps())
+}
+
+func TestVirtualTimers(t *testing.T) {
+	s := promisetest.NewFIFO()
+	var fired []string
+	s.AfterFunc(2*time.Second, func() { fired = append(fired, "b") })
+	s.AfterFunc(time.Second, func() { fired = append(fired, "a") })
+	stopped := s.AfterFunc(time.Second, func() { fired = append(fired, "stopped") })
+	require.True(t, stopped.Stop())
+	require.False(t, stopped.Stop())
+
+	s.Advance(1500 * time.Millisecond)
+	require.Equal(t, []string{"a"}, fired)
+	require.Equal(t, promisetest.Epoch.Add(1500*time.Millisecond), s.Now())
+
+	s.Run()
+	require.Equal(t, []string{"a", "b"}, fired)
+	require.Equal(t, promisetest.Epoch.Add(2*time.Second), s.Now())
+}
+
+func TestWaitAdvancesTime(t *testing.T) {
+	s := promisetest.NewFIFO()
+	reset := s.Install()
+	defer reset()
+	p := promise.New[int]()
+	s.AfterFunc(time.Hour, func() { p.Resolve(42) })
+	v, err := p.Future().Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 42, v)
+	require.Equal(t, promisetest.Epoch.Add(time.Hour), s.Now())
+}
+
+func TestDeadlock(t *testing.T) {
+	s := promisetest.NewFIFO()
+	reset := s.Install()
+	defer reset()
+	p := promise.New[int]()
+	require.PanicsWithError(t, promisetest.ErrDeadlock.Error(), func() {
+		_, _ = p.Future().Wait(context.Background())
+	})
+}
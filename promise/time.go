@@ -0,0 +1,175 @@
+package promise
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrTimeout is the error of futures returned by WithTimeout when the timeout expires.
+// It wraps context.DeadlineExceeded.
+var ErrTimeout = fmt.Errorf("future timed out: %w", context.DeadlineExceeded)
+
+// Delay returns a future that is resolved after the duration d.
+func Delay(d time.Duration) Future[struct{}] {
+	p := New[struct{}]()
+	clock.AfterFunc(d, func() {
+		p.Resolve(struct{}{})
+	})
+	return p.Future()
+}
+
+// WithTimeout returns a future with the same result as fut, or that is rejected with ErrTimeout if fut does not
+// complete within the duration d.
+// The original future is not cancelled when the timeout expires.
+func WithTimeout[T any](fut Future[T], d time.Duration) Future[T] {
+	p := New[T]()
+	timer := clock.AfterFunc(d, func() {
+		p.Reject(ErrTimeout)
+	})
+	fut.OnComplete(func(v T, err error) {
+		timer.Stop()
+		p.complete(v, err)
+	})
+	return p.Future()
+}
+
+// RetryPolicy determines how often and with which delays Retry calls a function.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of calls, including the first one (unlimited if not positive).
+	MaxAttempts int
+	// InitialDelay is the delay before the second attempt.
+	InitialDelay time.Duration
+	// MaxDelay limits the delay between attempts (unlimited if not positive).
+	MaxDelay time.Duration
+	// Multiplier is the factor by which the delay grows after each attempt (2 if smaller than 1).
+	Multiplier float64
+	// Jitter is the fraction in [0, 1] by which each delay is randomly shortened.
+	Jitter float64
+	// ShouldRetry decides whether an attempt that failed with the given error is retried (all errors are retried if nil).
+	ShouldRetry func(err error) bool
+	// Random returns a random number in [0, 1) used for the jitter (uses math/rand if nil).
+	Random func() float64
+}
+
+// ExponentialBackoff returns a policy that doubles the delay after each attempt, starting with initialDelay.
+func ExponentialBackoff(maxAttempts int, initialDelay, maxDelay time.Duration) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  maxAttempts,
+		InitialDelay: initialDelay,
+		MaxDelay:     maxDelay,
+		Multiplier:   2,
+	}
+}
+
+// RetryIf returns a copy of the policy that only retries errors for which pred returns true.
+func (p RetryPolicy) RetryIf(pred func(err error) bool) RetryPolicy {
+	p.ShouldRetry = pred
+	return p
+}
+
+// Backoff returns the delay after the given failed attempt (starting with attempt 1).
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	if p.InitialDelay <= 0 {
+		return 0
+	}
+	delay := float64(p.InitialDelay) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+	if p.Jitter > 0 {
+		random := p.Random
+		if random == nil {
+			random = rand.Float64
+		}
+		delay = delay * (1 - p.Jitter*random())
+	}
+	// without a MaxDelay the delay can exceed the range of time.Duration
+	if delay >= float64(maxDuration) {
+		return maxDuration
+	}
+	return time.Duration(delay)
+}
+
+// maxDuration is the largest representable time.Duration
+const maxDuration = time.Duration(math.MaxInt64)
+
+// retry checks whether the given failed attempt should be retried
+func (p RetryPolicy) retry(attempt int, err error) bool {
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return false
+	}
+	return p.ShouldRetry == nil || p.ShouldRetry(err)
+}
+
+// Retry calls f asynchronously until it succeeds, the policy gives up, or ctx is done.
+// The resulting future is rejected with the error of the last attempt, or with the error of ctx if it is done.
+// When ctx is done or the future is cancelled with Future.Cancel, the future is rejected without waiting for
+// the current attempt or the next backoff, and the context passed to f is cancelled.
+func Retry[T any](ctx context.Context, policy RetryPolicy, f func(ctx context.Context) (T, error)) Future[T] {
+	p := New[T]()
+	// the watcher below runs on its own goroutine, so it must not read the global clock
+	c := clock
+	workCtx, cancel := context.WithCancel(ctx)
+	p.data.cancelWork = cancel
+	// mutex protects timer, the pending backoff (nil while an attempt is running)
+	var mutex sync.Mutex
+	var timer Timer
+	stopWatching := afterDone(workCtx, func() {
+		// reject via the clock, so that the rejection is ordered with the other timers
+		c.AfterFunc(0, func() {
+			p.Reject(workCtx.Err())
+		})
+	})
+	p.Future().OnComplete(func(T, error) {
+		stopWatching()
+		cancel()
+		mutex.Lock()
+		if timer != nil {
+			timer.Stop()
+		}
+		mutex.Unlock()
+	})
+	var attempt func(n int)
+	attempt = func(n int) {
+		if err := workCtx.Err(); err != nil {
+			p.Reject(err)
+			return
+		}
+		AsyncCtx(workCtx, f).OnComplete(func(v T, err error) {
+			if err == nil {
+				p.Resolve(v)
+				return
+			}
+			if ctxErr := workCtx.Err(); ctxErr != nil {
+				p.Reject(ctxErr)
+				return
+			}
+			if !policy.retry(n, err) {
+				p.Reject(err)
+				return
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			if workCtx.Err() != nil {
+				// ctx is done or the future was completed concurrently, so afterDone rejects it
+				return
+			}
+			timer = c.AfterFunc(policy.Backoff(n), func() {
+				mutex.Lock()
+				timer = nil
+				mutex.Unlock()
+				attempt(n + 1)
+			})
+		})
+	}
+	attempt(1)
+	return p.Future()
+}
@@ -0,0 +1,197 @@
+package promise_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/peterzeller/go-fun/promise"
+	"github.com/peterzeller/go-fun/promise/promisetest"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleRetry() {
+	s := promisetest.NewFIFO()
+	defer s.Install()()
+
+	attempts := 0
+	f := promise.Retry(context.Background(), promise.ExponentialBackoff(5, time.Second, time.Minute),
+		func(ctx context.Context) (int, error) {
+			attempts++
+			if attempts < 3 {
+				return 0, fmt.Errorf("attempt %d failed", attempts)
+			}
+			return 42, nil
+		})
+	v, err := f.Wait(context.Background())
+	fmt.Printf("v = %v, err = %v, attempts = %d, elapsed = %v\n", v, err, attempts, s.Now().Sub(promisetest.Epoch))
+	// output: v = 42, err = <nil>, attempts = 3, elapsed = 3s
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	s := promisetest.NewFIFO()
+	defer s.Install()()
+
+	attempts := 0
+	f := promise.Retry(context.Background(), promise.ExponentialBackoff(4, time.Second, 3*time.Second),
+		func(ctx context.Context) (int, error) {
+			attempts++
+			return 0, fmt.Errorf("attempt %d failed", attempts)
+		})
+	_, err := f.Wait(context.Background())
+	require.EqualError(t, err, "attempt 4 failed")
+	require.Equal(t, 4, attempts)
+	// delays: 1s, 2s, 3s (capped)
+	require.Equal(t, promisetest.Epoch.Add(6*time.Second), s.Now())
+}
+
+func TestRetryIf(t *testing.T) {
+	s := promisetest.NewFIFO()
+	defer s.Install()()
+
+	permanent := errors.New("permanent")
+	attempts := 0
+	policy := promise.ExponentialBackoff(10, time.Second, 0).RetryIf(func(err error) bool {
+		return !errors.Is(err, permanent)
+	})
+	f := promise.Retry(context.Background(), policy, func(ctx context.Context) (int, error) {
+		attempts++
+		if attempts == 2 {
+			return 0, permanent
+		}
+		return 0, fmt.Errorf("temporary")
+	})
+	_, err := f.Wait(context.Background())
+	require.ErrorIs(t, err, permanent)
+	require.Equal(t, 2, attempts)
+}
+
+func TestRetryContextCancelled(t *testing.T) {
+	s := promisetest.NewFIFO()
+	defer s.Install()()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	f := promise.Retry(ctx, promise.ExponentialBackoff(0, time.Second, 0), func(ctx context.Context) (int, error) {
+		attempts++
+		if attempts == 3 {
+			cancel()
+		}
+		return 0, fmt.Errorf("failed")
+	})
+	_, err := f.Wait(context.Background())
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 3, attempts)
+}
+
+func TestRetryCancelBetweenAttempts(t *testing.T) {
+	s := promisetest.NewFIFO()
+	defer s.Install()()
+
+	attempts := 0
+	f := promise.Retry(context.Background(), promise.ExponentialBackoff(10, time.Second, 0), func(ctx context.Context) (int, error) {
+		attempts++
+		return 0, fmt.Errorf("failed")
+	})
+	s.Advance(time.Second)
+	require.Equal(t, 2, attempts)
+	f.Cancel()
+	s.Run()
+	_, err := f.Wait(context.Background())
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 2, attempts)
+}
+
+func TestBackoffJitter(t *testing.T) {
+	policy := promise.RetryPolicy{
+		InitialDelay: 100 * time.Millisecond,
+		Multiplier:   3,
+		Jitter:       0.5,
+		Random:       func() float64 { return 0.5 },
+	}
+	require.Equal(t, 75*time.Millisecond, policy.Backoff(1))
+	require.Equal(t, 225*time.Millisecond, policy.Backoff(2))
+	require.Equal(t, 675*time.Millisecond, policy.Backoff(3))
+}
+
+func TestDelay(t *testing.T) {
+	s := promisetest.NewFIFO()
+	defer s.Install()()
+
+	_, err := promise.Delay(time.Minute).Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, promisetest.Epoch.Add(time.Minute), s.Now())
+}
+
+func TestWithTimeout(t *testing.T) {
+	s := promisetest.NewFIFO()
+	defer s.Install()()
+
+	slow := promise.Map(promise.Delay(time.Minute), func(struct{}) int { return 1 })
+	_, err := promise.WithTimeout(slow, time.Second).Wait(context.Background())
+	require.ErrorIs(t, err, promise.ErrTimeout)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, promisetest.Epoch.Add(time.Second), s.Now())
+
+	fast := promise.Map(promise.Delay(time.Second), func(struct{}) int { return 2 })
+	v, err := promise.WithTimeout(fast, time.Minute).Wait(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, v)
+}
+
+func TestWithTimeoutRealClock(t *testing.T) {
+	p := promise.New[int]()
+	_, err := promise.WithTimeout(p.Future(), time.Millisecond).Wait(context.Background())
+	require.ErrorIs(t, err, promise.ErrTimeout)
+}
+
+func TestBackoffUnlimitedDoesNotOverflow(t *testing.T) {
+	policy := promise.ExponentialBackoff(0, time.Second, 0)
+	prev := time.Duration(0)
+	for attempt := 1; attempt <= 2000; attempt++ {
+		d := policy.Backoff(attempt)
+		require.GreaterOrEqual(t, d, prev, "attempt %d", attempt)
+		prev = d
+	}
+	require.Equal(t, time.Duration(math.MaxInt64), policy.Backoff(100))
+
+	jitter := policy
+	jitter.Jitter = 0.5
+	jitter.Random = func() float64 { return 0.5 }
+	require.Greater(t, jitter.Backoff(1000), time.Duration(0))
+}
+
+// signalClock is a real clock that signals when a timer is created
+type signalClock struct {
+	promise.Clock
+	timerCreated chan struct{}
+}
+
+func (c signalClock) AfterFunc(d time.Duration, f func()) promise.Timer {
+	timer := c.Clock.AfterFunc(d, f)
+	c.timerCreated <- struct{}{}
+	return timer
+}
+
+func TestRetryCancelledDuringBackoff(t *testing.T) {
+	c := signalClock{promise.RealClock(), make(chan struct{}, 1)}
+	defer promise.SetClock(c)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	attempts := 0
+	f := promise.Retry(ctx, promise.ExponentialBackoff(0, time.Hour, 0), func(ctx context.Context) (int, error) {
+		attempts++
+		return 0, fmt.Errorf("failed")
+	})
+	// wait until the first attempt failed and the backoff started
+	<-c.timerCreated
+	cancel()
+	waitCtx, cancelWait := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelWait()
+	_, err := f.Wait(waitCtx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 1, attempts)
+}
@@ -12,6 +12,7 @@ func Map[A, B, C any](f func(A) B, r Reducer[B, C]) Reducer[A, C] {
 			Step: func(a A) bool {
 				return next.Step(f(a))
 			},
+			SizeHint: next.SizeHint,
 		}
 	}
 }
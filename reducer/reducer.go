@@ -9,14 +9,31 @@ type Reducer[A, B any] func() ReducerInstance[A, B]
 type ReducerInstance[A, B any] struct {
 	Complete func() B
 	Step     func(A) bool
+	// SizeHint is optional and is called before the first Step if the number of inputs is known in advance.
+	// Reducers can use it to pre-size buffers.
+	SizeHint func(n int)
+}
+
+// hint calls the SizeHint function, if present
+func (ri ReducerInstance[A, B]) hint(n int) {
+	if ri.SizeHint != nil {
+		ri.SizeHint(n)
+	}
 }
 
 func (r Reducer[A, B]) Apply(i iterable.Iterable[A]) B {
-	return r.ApplyIterator(i.Iterator())
+	ri := r()
+	if n, ok := iterable.KnownLength(i); ok {
+		ri.hint(n)
+	}
+	return applyIterator(ri, i.Iterator())
 }
 
 func (r Reducer[A, B]) ApplyIterator(it iterable.Iterator[A]) B {
-	ri := r()
+	return applyIterator(r(), it)
+}
+
+func applyIterator[A, B any](ri ReducerInstance[A, B], it iterable.Iterator[A]) B {
 	for {
 		a, ok := it.Next()
 		if !ok {
@@ -32,6 +49,7 @@ func (r Reducer[A, B]) ApplyIterator(it iterable.Iterator[A]) B {
 
 func (r Reducer[A, B]) ApplySlice(as []A) B {
 	ri := r()
+	ri.hint(len(as))
 	for _, a := range as {
 		cont := ri.Step(a)
 		if !cont {
@@ -195,3 +195,11 @@ func TestApplyMethod(t *testing.T) {
 func TestApplyIteratorMethod(t *testing.T) {
 	require.Equal(t, 5, reducer.Limit(3, reducer.Max[int]()).ApplyIterator(iterable.New(1, 5, 3, 10).Iterator()))
 }
+
+func TestSizeHint(t *testing.T) {
+	s := reducer.Apply(iterable.Range(0, 100),
+		reducer.Map(func(x int) int { return 99 - x },
+			reducer.Sorted(cmpInt, reducer.ToSlice[int]())))
+	require.Equal(t, iterable.ToSlice(iterable.Range(0, 100)), s)
+	require.Equal(t, 100, cap(s))
+}
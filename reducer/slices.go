@@ -1,5 +1,7 @@
 package reducer
 
+// ToSlice collects all elements in a slice.
+// The slice is pre-sized if the number of elements is known in advance.
 func ToSlice[A any]() Reducer[A, []A] {
 	return func() ReducerInstance[A, []A] {
 		res := make([]A, 0)
@@ -11,6 +13,9 @@ func ToSlice[A any]() Reducer[A, []A] {
 				res = append(res, a)
 				return true
 			},
+			SizeHint: func(n int) {
+				res = growSlice(res, n)
+			},
 		}
 	}
 
@@ -19,3 +24,13 @@ func ToSlice[A any]() Reducer[A, []A] {
 func ApplySlice[A, B any](s []A, reducer Reducer[A, B]) B {
 	return reducer.ApplySlice(s)
 }
+
+// growSlice ensures that the slice has capacity for n more elements
+func growSlice[A any](s []A, n int) []A {
+	if cap(s)-len(s) >= n {
+		return s
+	}
+	res := make([]A, len(s), len(s)+n)
+	copy(res, s)
+	return res
+}
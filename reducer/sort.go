@@ -5,6 +5,8 @@ import (
 	"github.com/peterzeller/go-fun/zero"
 )
 
+// Sorted sorts the inputs before passing them to the next reducer.
+// If the number of inputs is known in advance, the buffer is pre-sized and the size is forwarded to the next reducer.
 func Sorted[A, B any](cmp func(A, A) bool, next Reducer[A, B]) Reducer[A, B] {
 	return func() ReducerInstance[A, B] {
 		inputs := make([]A, 0)
@@ -27,6 +29,10 @@ func Sorted[A, B any](cmp func(A, A) bool, next Reducer[A, B]) Reducer[A, B] {
 				inputs = append(inputs, a)
 				return true
 			},
+			SizeHint: func(n int) {
+				inputs = growSlice(inputs, n)
+				nextI.hint(n)
+			},
 		}
 	}
 }
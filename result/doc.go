@@ -0,0 +1,7 @@
+/*
+Package result provides the Result data type, which holds either a value or an error.
+
+Result is useful to store the outcome of a computation that can fail, for example in collections or channels.
+It can be converted to and from the (T, error) pairs used by Go functions, optional values and futures.
+*/
+package result
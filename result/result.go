@@ -0,0 +1,249 @@
+package result
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/list"
+	"github.com/peterzeller/go-fun/opt"
+	"github.com/peterzeller/go-fun/promise"
+)
+
+// Result holds either a value of type T or an error.
+// Results are constructed with the Ok, Err or Of functions.
+// The zero value is Ok with the zero value of T.
+type Result[T any] struct {
+	value T
+	err   error
+}
+
+// Ok returns a successful result with the given value
+func Ok[T any](v T) Result[T] {
+	return Result[T]{value: v}
+}
+
+// ErrNilError is the panic value used when a failed result is created with a nil error.
+var ErrNilError = fmt.Errorf("failed result must have a non-nil error")
+
+// Err returns a failed result with the given error.
+// Panics with ErrNilError if the error is nil.
+func Err[T any](err error) Result[T] {
+	if err == nil {
+		panic(ErrNilError)
+	}
+	return Result[T]{err: err}
+}
+
+// Of creates a result from a value and an error, as returned by many Go functions.
+// The result is failed if the error is not nil.
+func Of[T any](v T, err error) Result[T] {
+	if err != nil {
+		return Result[T]{err: err}
+	}
+	return Ok(v)
+}
+
+// Try calls the function and captures its result.
+// A panic is turned into a failed result.
+func Try[T any](f func() (T, error)) (res Result[T]) {
+	defer func() {
+		r := recover()
+		if r != nil {
+			switch e := r.(type) {
+			case error:
+				res = Result[T]{err: e}
+			default:
+				res = Result[T]{err: fmt.Errorf("%v", r)}
+			}
+		}
+	}()
+	return Of(f())
+}
+
+// IsOk returns true if this result holds a value
+func (r Result[T]) IsOk() bool {
+	return r.err == nil
+}
+
+// IsErr returns true if this result holds an error
+func (r Result[T]) IsErr() bool {
+	return r.err != nil
+}
+
+// Get returns the value and the error of this result.
+// If the result is failed, the value is the zero value.
+func (r Result[T]) Get() (T, error) {
+	return r.value, r.err
+}
+
+// Value returns the value of this result.
+// Returns the zero value if the result is failed.
+func (r Result[T]) Value() T {
+	return r.value
+}
+
+// Err returns the error of this result, or nil if the result is successful.
+func (r Result[T]) Err() error {
+	return r.err
+}
+
+// OrElse returns the value if the result is successful, or else the given default value
+func (r Result[T]) OrElse(defaultValue T) T {
+	if r.err == nil {
+		return r.value
+	}
+	return defaultValue
+}
+
+// OrElseGet returns the value if the result is successful, or else the value returned by the given function
+func (r Result[T]) OrElseGet(defaultValue func(error) T) T {
+	if r.err == nil {
+		return r.value
+	}
+	return defaultValue(r.err)
+}
+
+// OrElsePanic returns the value if the result is successful, or else panics with the error
+func (r Result[T]) OrElsePanic() T {
+	if r.err == nil {
+		return r.value
+	}
+	panic(r.err)
+}
+
+// Filter returns this result if it is failed or the value satisfies the condition.
+// Otherwise, it returns a failed result with the given error.
+// The error must not be nil, otherwise Filter panics with ErrNilError when the condition is not satisfied.
+func (r Result[T]) Filter(cond func(T) bool, err error) Result[T] {
+	if r.err != nil || cond(r.value) {
+		return r
+	}
+	return Err[T](err)
+}
+
+// Or returns this result if it is successful, and the other result otherwise
+func (r Result[T]) Or(other Result[T]) Result[T] {
+	if r.err == nil {
+		return r
+	}
+	return other
+}
+
+// Recover returns this result if it is successful, and otherwise the result of applying the function to the error
+func (r Result[T]) Recover(f func(error) Result[T]) Result[T] {
+	if r.err == nil {
+		return r
+	}
+	return f(r.err)
+}
+
+// IfOk calls the function with the value, if the result is successful
+func (r Result[T]) IfOk(f func(T)) {
+	if r.err == nil {
+		f(r.value)
+	}
+}
+
+// IfErr calls the function with the error, if the result is failed
+func (r Result[T]) IfErr(f func(error)) {
+	if r.err != nil {
+		f(r.err)
+	}
+}
+
+// ToOptional returns the value as an optional value, which is None if the result is failed
+func (r Result[T]) ToOptional() opt.Optional[T] {
+	return opt.Make(r.value, r.err == nil)
+}
+
+// FromOptional creates a result from an optional value, using the given error if the value is absent.
+// The error must not be nil, otherwise FromOptional panics with ErrNilError when the value is absent.
+func FromOptional[T any](o opt.Optional[T], err error) Result[T] {
+	if v, ok := o.Get(); ok {
+		return Ok(v)
+	}
+	return Err[T](err)
+}
+
+// ToFuture returns a future that is already completed with this result
+func (r Result[T]) ToFuture() promise.Future[T] {
+	if r.err != nil {
+		return promise.Rejected[T](r.err)
+	}
+	return promise.Resolved(r.value)
+}
+
+// FromFuture waits for the future and returns its result.
+// If the context is done before the future completes, the result is failed with the context error.
+func FromFuture[T any](ctx context.Context, f promise.Future[T]) Result[T] {
+	return Of(f.Wait(ctx))
+}
+
+// String representation of the result
+func (r Result[T]) String() string {
+	if r.err != nil {
+		return fmt.Sprintf("Err(%v)", r.err)
+	}
+	return fmt.Sprintf("Ok(%v)", r.value)
+}
+
+// Map applies a function to the value, if the result is successful
+func Map[A, B any](r Result[A], f func(A) B) Result[B] {
+	if r.err != nil {
+		return Result[B]{err: r.err}
+	}
+	return Ok(f(r.value))
+}
+
+// FlatMap applies a function returning a result to the value, if the result is successful
+func FlatMap[A, B any](r Result[A], f func(A) Result[B]) Result[B] {
+	if r.err != nil {
+		return Result[B]{err: r.err}
+	}
+	return f(r.value)
+}
+
+// Then applies a function returning a value and an error to the value, if the result is successful
+func Then[A, B any](r Result[A], f func(A) (B, error)) Result[B] {
+	if r.err != nil {
+		return Result[B]{err: r.err}
+	}
+	return Of(f(r.value))
+}
+
+// Zip combines two results into a result of a pair.
+// If both results are failed, the error of the first one is returned.
+func Zip[A, B any](a Result[A], b Result[B]) Result[hash.Pair[A, B]] {
+	if a.err != nil {
+		return Result[hash.Pair[A, B]]{err: a.err}
+	}
+	if b.err != nil {
+		return Result[hash.Pair[A, B]]{err: b.err}
+	}
+	return Ok(hash.Pair[A, B]{A: a.value, B: b.value})
+}
+
+// Sequence turns an iterable of results into a result of a list.
+// The result is failed with the first error in the iterable.
+func Sequence[T any](i iterable.Iterable[Result[T]]) Result[list.List[T]] {
+	return Traverse(i, func(r Result[T]) Result[T] {
+		return r
+	})
+}
+
+// Traverse applies a function to all elements of the iterable and collects the results in a list.
+// The iteration stops at the first element where the function returns a failed result, and its error is returned.
+func Traverse[A, B any](i iterable.Iterable[A], f func(A) Result[B]) Result[list.List[B]] {
+	n, _ := iterable.KnownLength(i)
+	res := make([]B, 0, n)
+	for it := iterable.Start(i); it.HasNext(); it.Next() {
+		b, err := f(it.Current()).Get()
+		if err != nil {
+			return Result[list.List[B]]{err: err}
+		}
+		res = append(res, b)
+	}
+	return Ok(list.New(res...))
+}
@@ -0,0 +1,122 @@
+package result_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/list/list"
+	"github.com/peterzeller/go-fun/opt"
+	"github.com/peterzeller/go-fun/promise"
+	"github.com/peterzeller/go-fun/result"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleOf() {
+	r1 := result.Of(strconv.Atoi("42"))
+	r2 := result.Of(strconv.Atoi("x"))
+	fmt.Println(r1)
+	fmt.Println(r2)
+	// output:
+	// Ok(42)
+	// Err(strconv.Atoi: parsing "x": invalid syntax)
+}
+
+func ExampleTraverse() {
+	parse := func(s string) result.Result[int] {
+		return result.Of(strconv.Atoi(s))
+	}
+	fmt.Println(result.Traverse(iterable.New("1", "2", "3"), parse))
+	fmt.Println(result.Traverse(iterable.New("1", "b", "c"), parse))
+	// output:
+	// Ok([1, 2, 3])
+	// Err(strconv.Atoi: parsing "b": invalid syntax)
+}
+
+var errTest = fmt.Errorf("test error")
+
+func TestCombinators(t *testing.T) {
+	ok := result.Ok(21)
+	failed := result.Err[int](errTest)
+	double := func(x int) int { return 2 * x }
+
+	require.Equal(t, result.Ok(42), result.Map(ok, double))
+	require.ErrorIs(t, result.Map(failed, double).Err(), errTest)
+
+	half := func(x int) result.Result[int] {
+		if x%2 != 0 {
+			return result.Err[int](fmt.Errorf("%d is odd", x))
+		}
+		return result.Ok(x / 2)
+	}
+	require.EqualError(t, result.FlatMap(ok, half).Err(), "21 is odd")
+	require.Equal(t, result.Ok(21), result.FlatMap(result.Ok(42), half))
+	require.Equal(t, result.Ok(42), result.Then(ok, func(x int) (int, error) { return 2 * x, nil }))
+
+	positive := func(x int) bool { return x > 0 }
+	require.Equal(t, ok, ok.Filter(positive, errTest))
+	require.ErrorIs(t, result.Ok(-1).Filter(positive, errTest).Err(), errTest)
+
+	require.Equal(t, ok, ok.Or(result.Ok(1)))
+	require.Equal(t, result.Ok(1), failed.Or(result.Ok(1)))
+	require.Equal(t, result.Ok(7), failed.Recover(func(err error) result.Result[int] { return result.Ok(7) }))
+
+	require.Equal(t, 21, ok.OrElse(0))
+	require.Equal(t, 0, failed.OrElse(0))
+	require.Equal(t, 10, failed.OrElseGet(func(err error) int { return len(err.Error()) }))
+	require.Panics(t, func() { failed.OrElsePanic() })
+	require.Panics(t, func() { result.Err[int](nil) })
+
+	calls := 0
+	ok.IfOk(func(int) { calls++ })
+	failed.IfOk(func(int) { calls++ })
+	failed.IfErr(func(error) { calls += 10 })
+	ok.IfErr(func(error) { calls += 10 })
+	require.Equal(t, 11, calls)
+
+	z := result.Zip(ok, result.Ok("x"))
+	require.True(t, z.IsOk())
+	require.Equal(t, 21, z.Value().A)
+	require.Equal(t, "x", z.Value().B)
+	require.ErrorIs(t, result.Zip(result.Ok("x"), failed).Err(), errTest)
+}
+
+func TestSequence(t *testing.T) {
+	require.Equal(t, result.Ok(list.New(1, 2)), result.Sequence(iterable.New(result.Ok(1), result.Ok(2))))
+	require.Equal(t, result.Ok(list.New[int]()), result.Sequence(iterable.Empty[result.Result[int]]()))
+	r := result.Sequence(iterable.New(result.Ok(1), result.Err[int](errTest), result.Err[int](fmt.Errorf("other"))))
+	require.ErrorIs(t, r.Err(), errTest)
+}
+
+func TestTry(t *testing.T) {
+	r := result.Try(func() (int, error) {
+		panic(errTest)
+	})
+	require.ErrorIs(t, r.Err(), errTest)
+	r = result.Try(func() (int, error) {
+		panic("boom")
+	})
+	require.EqualError(t, r.Err(), "boom")
+	require.Equal(t, result.Ok(1), result.Try(func() (int, error) { return 1, nil }))
+}
+
+func TestOptional(t *testing.T) {
+	require.Equal(t, opt.Some(1), result.Ok(1).ToOptional())
+	require.Equal(t, opt.None[int](), result.Err[int](errTest).ToOptional())
+	require.Equal(t, result.Ok(1), result.FromOptional(opt.Some(1), errTest))
+	require.ErrorIs(t, result.FromOptional(opt.None[int](), errTest).Err(), errTest)
+}
+
+func TestFuture(t *testing.T) {
+	ctx := context.Background()
+	require.Equal(t, result.Ok(1), result.FromFuture(ctx, result.Ok(1).ToFuture()))
+	require.ErrorIs(t, result.FromFuture(ctx, result.Err[int](errTest).ToFuture()).Err(), errTest)
+
+	p := promise.New[int]()
+	ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, result.FromFuture(ctx, p.Future()).Err(), context.DeadlineExceeded)
+}
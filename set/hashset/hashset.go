@@ -2,19 +2,30 @@ package hashset
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/peterzeller/go-fun/dict"
 	"github.com/peterzeller/go-fun/dict/hashdict"
+	"github.com/peterzeller/go-fun/equality"
 	"github.com/peterzeller/go-fun/hash"
 	"github.com/peterzeller/go-fun/iterable"
 	"github.com/peterzeller/go-fun/reducer"
 )
 
+// Set is an immutable hash set.
+//
+// Operations that combine two sets, like Union or Intersect, merge the underlying tries directly.
+// This is only correct if both sets use the same EqHash, which cannot be checked at runtime,
+// because EqHash instances are usually not comparable.
+// Mixing sets with different EqHash instances, for example hash.String and hash.StringIgnoreCase,
+// gives wrong results. Convert one of the sets with FromIterable first in that case.
 type Set[T any] struct {
 	dict hashdict.Dict[T, struct{}]
 }
 
+var _ equality.Equal[Set[int]] = Set[int]{}
+
 // New creates a new set
 func New[T any](eq hash.EqHash[T], elems ...T) Set[T] {
 	entries := make([]dict.Entry[T, struct{}], len(elems))
@@ -24,21 +35,41 @@ func New[T any](eq hash.EqHash[T], elems ...T) Set[T] {
 	return Set[T]{dict: hashdict.New(eq, entries...)}
 }
 
+// FromIterable creates a new set containing the elements of the given iterable.
+// The elements are added one by one, so elems can also be a Set with a different EqHash.
+func FromIterable[T any](eq hash.EqHash[T], elems iterable.Iterable[T]) Set[T] {
+	d := hashdict.New[T, struct{}](eq)
+	for it := elems.Iterator(); ; {
+		x, ok := it.Next()
+		if !ok {
+			break
+		}
+		d = d.SetIfAbsent(x, struct{}{})
+	}
+	return Set[T]{dict: d}
+}
+
 // EqHash returns the hash instance used in the set
 func (s Set[T]) EqHash() hash.EqHash[T] {
 	return s.dict.KeyEq()
 }
 
+// Size returns the number of elements in the set
+func (s Set[T]) Size() int {
+	return s.dict.Size()
+}
+
 // Contains checks if the set contains an element
 func (s Set[T]) Contains(elem T) bool {
 	return s.dict.ContainsKey(elem)
 }
 
-// Add elements to the set
+// Add elements to the set.
+// If all elements are already contained in the set, the original set is returned.
 func (s Set[T]) Add(elems ...T) Set[T] {
 	d := s.dict
 	for _, e := range elems {
-		d = d.Set(e, struct{}{})
+		d = d.SetIfAbsent(e, struct{}{})
 	}
 	return Set[T]{dict: d}
 }
@@ -59,9 +90,21 @@ func (s Set[T]) Iterator() iterable.Iterator[T] {
 }
 
 // Union of two sets, returning elements that return in either of the sets
+// If other is a Set, it must use the same EqHash as this set.
 func (s Set[T]) Union(other iterable.Iterable[T]) Set[T] {
 	if otherS, ok := other.(Set[T]); ok {
-		d := s.dict.Merge(otherS.dict, func(k T, v1, v2 struct{}) struct{} { return struct{}{} })
+		d := s.dict.MergeAll(otherS.dict, hashdict.MergeOpts[T, struct{}, struct{}, struct{}]{
+			Left:  func(k T, a struct{}) (struct{}, bool) { return struct{}{}, true },
+			Right: func(k T, b struct{}) (struct{}, bool) { return struct{}{}, true },
+			Both: func(k T, a, b struct{}) (struct{}, bool) {
+				return struct{}{}, true
+			},
+			// all values are the same, so either side can be reused
+			LeftUnchanged:  true,
+			RightUnchanged: true,
+			BothLeft:       true,
+			BothRight:      true,
+		})
 		return Set[T]{dict: d}
 	}
 	d := s.dict
@@ -70,12 +113,13 @@ func (s Set[T]) Union(other iterable.Iterable[T]) Set[T] {
 		if !ok {
 			break
 		}
-		d = d.Set(x, struct{}{})
+		d = d.SetIfAbsent(x, struct{}{})
 	}
 	return Set[T]{dict: d}
 }
 
 // Intersect two sets, returning only elements contained in both
+// If other is a Set, it must use the same EqHash as this set.
 func (s Set[T]) Intersect(other iterable.Iterable[T]) Set[T] {
 	if otherS, ok := other.(Set[T]); ok {
 		d := s.dict.MergeAll(otherS.dict, hashdict.MergeOpts[T, struct{}, struct{}, struct{}]{
@@ -84,23 +128,17 @@ func (s Set[T]) Intersect(other iterable.Iterable[T]) Set[T] {
 			Both: func(k T, a, b struct{}) (struct{}, bool) {
 				return struct{}{}, true
 			},
+			BothLeft:  true,
+			BothRight: true,
 		})
 		return Set[T]{dict: d}
 	}
-	res := New(s.EqHash())
-	for it := other.Iterator(); ; {
-		x, ok := it.Next()
-		if !ok {
-			break
-		}
-		if s.Contains(x) {
-			res = res.Add(x)
-		}
-	}
-	return res
+	// build a set from the other elements, so that the trie-level merge can be used
+	return s.Intersect(s.fromIterable(other))
 }
 
 // Minus removes all elements from the other set from this set
+// If other is a Set, it must use the same EqHash as this set.
 func (s Set[T]) Minus(other iterable.Iterable[T]) Set[T] {
 	if otherS, ok := other.(Set[T]); ok {
 		d := s.dict.MergeAll(otherS.dict, hashdict.MergeOpts[T, struct{}, struct{}, struct{}]{
@@ -109,22 +147,209 @@ func (s Set[T]) Minus(other iterable.Iterable[T]) Set[T] {
 			Both: func(k T, a, b struct{}) (struct{}, bool) {
 				return struct{}{}, false
 			},
+			LeftUnchanged: true,
 		})
 		return Set[T]{dict: d}
 	}
-	res := New(s.EqHash())
+	d := s.dict
+	for it := other.Iterator(); ; {
+		x, ok := it.Next()
+		if !ok {
+			break
+		}
+		d = d.Remove(x)
+	}
+	return Set[T]{dict: d}
+}
+
+// SymmetricDifference returns the elements that are contained in exactly one of the two sets
+// If other is a Set, it must use the same EqHash as this set.
+func (s Set[T]) SymmetricDifference(other iterable.Iterable[T]) Set[T] {
+	otherS := s.fromIterable(other)
+	d := s.dict.MergeAll(otherS.dict, hashdict.MergeOpts[T, struct{}, struct{}, struct{}]{
+		Left:  func(k T, a struct{}) (struct{}, bool) { return struct{}{}, true },
+		Right: func(k T, b struct{}) (struct{}, bool) { return struct{}{}, true },
+		Both: func(k T, a, b struct{}) (struct{}, bool) {
+			return struct{}{}, false
+		},
+		LeftUnchanged:  true,
+		RightUnchanged: true,
+	})
+	return Set[T]{dict: d}
+}
+
+// UnionAll returns the union of this set with all the other collections
+// Collections that are Sets must use the same EqHash as this set.
+func (s Set[T]) UnionAll(others ...iterable.Iterable[T]) Set[T] {
+	sets := make([]Set[T], 0, len(others)+1)
+	sets = append(sets, s)
+	for _, o := range others {
+		sets = append(sets, s.fromIterable(o))
+	}
+	// merge in a balanced way, so that large intermediate results are not merged repeatedly
+	for len(sets) > 1 {
+		merged := make([]Set[T], 0, (len(sets)+1)/2)
+		for i := 0; i+1 < len(sets); i += 2 {
+			merged = append(merged, sets[i].Union(sets[i+1]))
+		}
+		if len(sets)%2 == 1 {
+			merged = append(merged, sets[len(sets)-1])
+		}
+		sets = merged
+	}
+	return sets[0]
+}
+
+// IntersectAll returns the elements of this set that are contained in all the other collections
+// Collections that are Sets must use the same EqHash as this set.
+func (s Set[T]) IntersectAll(others ...iterable.Iterable[T]) Set[T] {
+	sets := make([]Set[T], 0, len(others)+1)
+	sets = append(sets, s)
+	for _, o := range others {
+		sets = append(sets, s.fromIterable(o))
+	}
+	// start with the smallest sets to keep intermediate results small
+	sort.SliceStable(sets, func(i, j int) bool {
+		return sets[i].Size() < sets[j].Size()
+	})
+	res := sets[0]
+	for _, o := range sets[1:] {
+		if res.Size() == 0 {
+			break
+		}
+		res = res.Intersect(o)
+	}
+	return res
+}
+
+// IsSubsetOf checks whether all elements of this set are contained in the other collection
+// If other is a Set, it must use the same EqHash as this set.
+func (s Set[T]) IsSubsetOf(other iterable.Iterable[T]) bool {
+	otherS := s.fromIterable(other)
+	if s.Size() > otherS.Size() {
+		return false
+	}
+	return s.Minus(otherS).Size() == 0
+}
+
+// IsSupersetOf checks whether all elements of the other collection are contained in this set
+// If other is a Set, it must use the same EqHash as this set.
+func (s Set[T]) IsSupersetOf(other iterable.Iterable[T]) bool {
+	if otherS, ok := other.(Set[T]); ok {
+		return otherS.IsSubsetOf(s)
+	}
+	return !reducer.Apply(other, reducer.Exists(func(x T) bool { return !s.Contains(x) }))
+}
+
+// Disjoint checks whether the set has no elements in common with the other collection
+func (s Set[T]) Disjoint(other iterable.Iterable[T]) bool {
+	if otherS, ok := other.(Set[T]); ok && otherS.Size() > s.Size() {
+		// iterate over the smaller set
+		return otherS.Disjoint(s)
+	}
+	return !reducer.Apply(other, reducer.Exists(s.Contains))
+}
+
+// Equal checks whether both sets contain the same elements
+// Both sets must use the same EqHash.
+func (s Set[T]) Equal(other Set[T]) bool {
+	if s.Same(other) {
+		return true
+	}
+	return s.Size() == other.Size() && s.IsSubsetOf(other)
+}
+
+// fromIterable converts the iterable to a set using the same EqHash as this set.
+// If the iterable already is a set, it is returned unchanged, so it must use the same EqHash (see Set).
+func (s Set[T]) fromIterable(other iterable.Iterable[T]) Set[T] {
+	if otherS, ok := other.(Set[T]); ok {
+		return otherS
+	}
+	return FromIterable(s.EqHash(), other)
+}
+
+// Filter returns the elements of the set that satisfy the predicate.
+// If all elements satisfy the predicate, the original set is returned.
+func (s Set[T]) Filter(pred func(T) bool) Set[T] {
+	return Set[T]{dict: s.dict.Filter(func(k T, _ struct{}) bool {
+		return pred(k)
+	})}
+}
+
+// Partition splits the set into the elements that satisfy the predicate and the elements that do not.
+func (s Set[T]) Partition(pred func(T) bool) (Set[T], Set[T]) {
+	yes := s.Filter(pred)
+	if yes.Size() == s.Size() {
+		return yes, New(s.EqHash())
+	}
+	return yes, s.Minus(yes)
+}
+
+// Map applies f to all elements of the set.
+// The resulting set uses eqB to compare elements.
+func Map[A, B any](s Set[A], eqB hash.EqHash[B], f func(A) B) Set[B] {
+	res := hashdict.New[B, struct{}](eqB)
 	for it := s.Iterator(); ; {
 		x, ok := it.Next()
 		if !ok {
 			break
 		}
-		if !reducer.Apply(other, reducer.Exists(func(y T) bool { return s.EqHash().Equal(x, y) })) {
-			res = res.Add(x)
+		res = res.SetIfAbsent(f(x), struct{}{})
+	}
+	return Set[B]{dict: res}
+}
+
+// FlatMap applies f to all elements of the set and returns the union of the results.
+// The resulting set uses eqB to compare elements.
+func FlatMap[A, B any](s Set[A], eqB hash.EqHash[B], f func(A) iterable.Iterable[B]) Set[B] {
+	res := New(eqB)
+	for it := s.Iterator(); ; {
+		x, ok := it.Next()
+		if !ok {
+			break
 		}
+		res = res.Union(f(x))
 	}
 	return res
 }
 
+// Any checks whether some element of the set satisfies the predicate
+func (s Set[T]) Any(pred func(T) bool) bool {
+	return reducer.Apply[T](s, reducer.Exists(pred))
+}
+
+// Every checks whether all elements of the set satisfy the predicate
+func (s Set[T]) Every(pred func(T) bool) bool {
+	return !s.Any(func(x T) bool { return !pred(x) })
+}
+
+// Choose returns some element of the set.
+// The choice is deterministic: the same set always returns the same element.
+// Returns false if the set is empty.
+func (s Set[T]) Choose() (T, bool) {
+	e, ok := s.dict.First()
+	return e.Key, ok
+}
+
+// Pop removes some element from the set and returns the element and the remaining set.
+// The element is the same as the one returned by Choose.
+// Returns false if the set is empty.
+func (s Set[T]) Pop() (T, Set[T], bool) {
+	x, ok := s.Choose()
+	if !ok {
+		return x, s, false
+	}
+	return x, s.Remove(x), true
+}
+
+// Same returns true if both sets share the same underlying structure.
+// In this case the sets are equal.
+// This is a cheap check, so it can be used to detect that an operation did not change the set.
+// If Same returns false, the sets might still be equal.
+func (s Set[T]) Same(other Set[T]) bool {
+	return s.dict.Same(other.dict)
+}
+
 // String representation of the set
 func (s Set[T]) String() string {
 	var res strings.Builder
@@ -2,9 +2,13 @@ package hashset_test
 
 import (
 	"fmt"
+	"math/rand"
+	"testing"
 
 	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
 	"github.com/peterzeller/go-fun/set/hashset"
+	"github.com/stretchr/testify/require"
 )
 
 func ExampleSet_Contains() {
@@ -89,3 +93,232 @@ func ExampleSet_Minus() {
 	// s2 = [e, b, d]
 	// s3 = [a, c]
 }
+
+func ExampleSet_Same() {
+	s1 := hashset.New(hash.String(), "a", "b", "c")
+	s2 := s1.Add("a")
+	s3 := s1.Add("x")
+	fmt.Printf("s1.Same(s2) = %v\n", s1.Same(s2))
+	fmt.Printf("s1.Same(s3) = %v\n", s1.Same(s3))
+	// output:
+	// s1.Same(s2) = true
+	// s1.Same(s3) = false
+}
+
+func TestSharing(t *testing.T) {
+	var elems []int
+	for i := 0; i < 1000; i++ {
+		elems = append(elems, i)
+	}
+	s := hashset.New(hash.Num[int](), elems...)
+	evens := hashset.New(hash.Num[int]())
+	for i := 0; i < 1000; i += 2 {
+		evens = evens.Add(i)
+	}
+	disjoint := hashset.New(hash.Num[int](), -1, -2, 1000, 1001)
+	empty := hashset.New(hash.Num[int]())
+
+	require.True(t, s.Same(s.Add(1, 2, 3)), "add existing")
+	require.True(t, s.Same(s.Remove(-1, 1000)), "remove absent")
+	require.True(t, s.Same(s.Union(s)), "union with itself")
+	require.True(t, s.Same(s.Union(evens)), "union with subset")
+	require.True(t, s.Same(evens.Union(s)), "union of subset with superset")
+	require.True(t, s.Same(s.Union(empty)), "union with empty")
+	require.True(t, evens.Same(evens.Intersect(s)), "intersect with superset")
+	require.True(t, evens.Same(s.Intersect(evens)), "intersect superset with subset")
+	require.True(t, s.Same(s.Minus(disjoint)), "minus disjoint")
+	require.True(t, s.Same(s.Minus(empty)), "minus empty")
+	require.False(t, s.Same(s.Minus(evens)), "minus subset")
+}
+
+func ExampleSet_SymmetricDifference() {
+	s1 := hashset.New(hash.String(), "a", "b", "c")
+	s2 := hashset.New(hash.String(), "b", "c", "d")
+	fmt.Printf("%v\n", s1.SymmetricDifference(s2))
+	// output: [a, d]
+}
+
+func ExampleSet_UnionAll() {
+	s1 := hashset.New(hash.String(), "a")
+	s2 := hashset.New(hash.String(), "b")
+	s3 := s1.UnionAll(s2, iterable.New("c", "d"))
+	fmt.Printf("%v\n", s3)
+	// output: [b, a, c, d]
+}
+
+func ExampleSet_IntersectAll() {
+	s1 := hashset.New(hash.String(), "a", "b", "c", "d")
+	s2 := hashset.New(hash.String(), "b", "c", "d")
+	s3 := s1.IntersectAll(s2, iterable.New("a", "c", "d"))
+	fmt.Printf("%v\n", s3)
+	// output: [c, d]
+}
+
+func ExampleSet_IsSubsetOf() {
+	s1 := hashset.New(hash.String(), "a", "b")
+	s2 := hashset.New(hash.String(), "a", "b", "c")
+	fmt.Printf("s1 subset of s2: %v\n", s1.IsSubsetOf(s2))
+	fmt.Printf("s2 subset of s1: %v\n", s2.IsSubsetOf(s1))
+	fmt.Printf("s2 superset of s1: %v\n", s2.IsSupersetOf(s1))
+	// output:
+	// s1 subset of s2: true
+	// s2 subset of s1: false
+	// s2 superset of s1: true
+}
+
+func ExampleSet_Disjoint() {
+	s1 := hashset.New(hash.String(), "a", "b")
+	fmt.Printf("%v\n", s1.Disjoint(iterable.New("c", "d")))
+	fmt.Printf("%v\n", s1.Disjoint(iterable.New("b", "c")))
+	// output:
+	// true
+	// false
+}
+
+func ExampleSet_Equal() {
+	s1 := hashset.New(hash.String(), "a", "b")
+	s2 := hashset.New(hash.String(), "b", "a")
+	s3 := hashset.New(hash.String(), "a", "c")
+	fmt.Printf("s1 = s2: %v\n", s1.Equal(s2))
+	fmt.Printf("s1 = s3: %v\n", s1.Equal(s3))
+	// output:
+	// s1 = s2: true
+	// s1 = s3: false
+}
+
+// TestMixedInputs checks that set operations with other iterables give the same results as with sets
+func TestFromIterableOtherEqHash(t *testing.T) {
+	words := []string{"a", "A", "b", "B", "c", "Hello", "HELLO", "hello"}
+	exact := hashset.New(hash.String(), words...)
+	ignoreCase := hashset.FromIterable[string](hash.StringIgnoreCase(), exact)
+	require.Equal(t, 4, ignoreCase.Size())
+	other := hashset.New(hash.StringIgnoreCase(), "a", "hello", "x")
+	require.Equal(t, 2, ignoreCase.Intersect(other).Size())
+	require.True(t, ignoreCase.Union(other).Contains("X"))
+}
+
+func TestMixedInputs(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		randomElems := func() []int {
+			res := make([]int, r.Intn(40))
+			for i := range res {
+				res[i] = r.Intn(50)
+			}
+			return res
+		}
+		as := randomElems()
+		bs := randomElems()
+		a := hashset.New(hash.Num[int](), as...)
+		b := hashset.New(hash.Num[int](), bs...)
+		bIt := iterable.New(bs...)
+
+		require.True(t, a.Union(b).Equal(a.Union(bIt)))
+		require.True(t, a.Intersect(b).Equal(a.Intersect(bIt)))
+		require.True(t, a.Minus(b).Equal(a.Minus(bIt)))
+		require.True(t, a.SymmetricDifference(b).Equal(a.SymmetricDifference(bIt)))
+		require.True(t, a.SymmetricDifference(b).Equal(a.Union(b).Minus(a.Intersect(b))))
+		require.Equal(t, a.IsSubsetOf(b), a.IsSubsetOf(bIt))
+		require.Equal(t, a.IsSupersetOf(b), a.IsSupersetOf(bIt))
+		require.Equal(t, a.Minus(b).Equal(a), a.Disjoint(b))
+		require.Equal(t, a.Disjoint(b), a.Disjoint(bIt))
+		require.Equal(t, a.IsSubsetOf(b) && b.IsSubsetOf(a), a.Equal(b))
+		require.True(t, a.Union(b).Equal(a.UnionAll(b, bIt, a)))
+		require.True(t, a.Intersect(b).Equal(a.IntersectAll(bIt, a, b)))
+		for _, x := range as {
+			require.True(t, a.Union(b).Contains(x))
+			require.Equal(t, b.Contains(x), a.Intersect(b).Contains(x))
+			require.Equal(t, !b.Contains(x), a.Minus(b).Contains(x))
+		}
+	}
+}
+
+func ExampleFromIterable() {
+	s := hashset.FromIterable(hash.String(), iterable.New("a", "b", "a"))
+	fmt.Printf("s = %v, size = %d\n", s, s.Size())
+	// output: s = [b, a], size = 2
+}
+
+func ExampleSet_Filter() {
+	s := hashset.New(hash.Num[int](), 1, 2, 3, 4, 5)
+	fmt.Printf("%v\n", s.Filter(func(x int) bool { return x%2 == 0 }))
+	// output: [2, 4]
+}
+
+func ExampleSet_Partition() {
+	s := hashset.New(hash.Num[int](), 1, 2, 3, 4, 5)
+	even, odd := s.Partition(func(x int) bool { return x%2 == 0 })
+	fmt.Printf("even = %v, odd = %v\n", even, odd)
+	// output: even = [2, 4], odd = [1, 3, 5]
+}
+
+func ExampleMap() {
+	s := hashset.New(hash.String(), "a", "bb", "cc")
+	lengths := hashset.Map(s, hash.Num[int](), func(x string) int { return len(x) })
+	fmt.Printf("%v\n", lengths)
+	// output: [1, 2]
+}
+
+func ExampleFlatMap() {
+	s := hashset.New(hash.Num[int](), 1, 3)
+	res := hashset.FlatMap(s, hash.Num[int](), func(x int) iterable.Iterable[int] {
+		return iterable.New(x, x+1)
+	})
+	fmt.Printf("%v\n", res)
+	// output: [1, 2, 3, 4]
+}
+
+func ExampleSet_Any() {
+	s := hashset.New(hash.Num[int](), 1, 2, 3)
+	fmt.Printf("any > 2: %v\n", s.Any(func(x int) bool { return x > 2 }))
+	fmt.Printf("every > 2: %v\n", s.Every(func(x int) bool { return x > 2 }))
+	// output:
+	// any > 2: true
+	// every > 2: false
+}
+
+func ExampleSet_Pop() {
+	s := hashset.New(hash.Num[int](), 1, 2, 3)
+	for {
+		x, rest, ok := s.Pop()
+		if !ok {
+			break
+		}
+		fmt.Printf("%v ", x)
+		s = rest
+	}
+	// output: 1 2 3
+}
+
+func TestFunctionalAPI(t *testing.T) {
+	var elems []int
+	for i := 0; i < 500; i++ {
+		elems = append(elems, i)
+	}
+	s := hashset.New(hash.Num[int](), elems...)
+	require.Equal(t, 500, s.Size())
+	require.True(t, s.Same(s.Filter(func(x int) bool { return true })))
+	require.Equal(t, 0, s.Filter(func(x int) bool { return false }).Size())
+
+	small, large := s.Partition(func(x int) bool { return x < 100 })
+	require.Equal(t, 100, small.Size())
+	require.Equal(t, 400, large.Size())
+	require.True(t, small.Disjoint(large))
+	require.True(t, s.Equal(small.Union(large)))
+
+	mod := hashset.Map(s, hash.Num[int](), func(x int) int { return x % 7 })
+	require.Equal(t, 7, mod.Size())
+	require.True(t, s.Every(func(x int) bool { return mod.Contains(x % 7) }))
+
+	x, ok := s.Choose()
+	require.True(t, ok)
+	require.True(t, s.Contains(x))
+	y, rest, ok := s.Pop()
+	require.True(t, ok)
+	require.Equal(t, x, y)
+	require.False(t, rest.Contains(y))
+	require.Equal(t, 499, rest.Size())
+
+	_, ok = hashset.New(hash.Num[int]()).Choose()
+	require.False(t, ok)
+}
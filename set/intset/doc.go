@@ -0,0 +1,12 @@
+/*
+Package intset implements an immutable set of integers.
+
+The set is implemented as a big-endian Patricia trie with bitmap leaves, as in Okasaki and Gill's
+"Fast Mergeable Integer Maps": Each leaf stores 64 consecutive integers as a bitmap.
+This makes dense sets much more compact than a hashset.Set[int] and set operations like Union,
+Intersect and Minus can work on whole subtrees and bitmaps at once.
+Subtrees are shared between versions of a set and unchanged subtrees are reused by the set operations.
+
+Elements are iterated in ascending order.
+*/
+package intset
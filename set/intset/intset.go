@@ -0,0 +1,191 @@
+package intset
+
+import (
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/set/hashset"
+)
+
+// Set is an immutable set of integers.
+// The zero value is an empty set.
+type Set struct {
+	root *node
+}
+
+var _ equality.Equal[Set] = Set{}
+
+// New creates a new set with the given elements.
+func New(elems ...int) Set {
+	var root *node
+	for _, x := range elems {
+		k := toKey(x)
+		root = root.insertLeaf(leafPrefix(k), bitOf(k))
+	}
+	return Set{root}
+}
+
+// FromIterable creates a new set from the given elements.
+func FromIterable(elems iterable.Iterable[int]) Set {
+	if s, ok := elems.(Set); ok {
+		return s
+	}
+	var root *node
+	for it := iterable.Start(elems); it.HasNext(); it.Next() {
+		k := toKey(it.Current())
+		root = root.insertLeaf(leafPrefix(k), bitOf(k))
+	}
+	return Set{root}
+}
+
+// FromHashSet converts a hashset.Set to an integer set.
+func FromHashSet(s hashset.Set[int]) Set {
+	return FromIterable(s)
+}
+
+// Range creates a set containing the integers from start (inclusive) to end (exclusive).
+func Range(start, end int) Set {
+	var root *node
+	for x := start; x < end; {
+		k := toKey(x)
+		prefix := leafPrefix(k)
+		// add all elements in this leaf at once
+		n := leafBits - int(k-prefix)
+		if end-x < n {
+			n = end - x
+		}
+		var bm uint64
+		if n == leafBits {
+			bm = ^uint64(0)
+		} else {
+			bm = ((1 << uint(n)) - 1) << (k - prefix)
+		}
+		root = root.insertLeaf(prefix, bm)
+		x += n
+	}
+	return Set{root}
+}
+
+// ToHashSet converts the set to a hashset.Set.
+func (s Set) ToHashSet() hashset.Set[int] {
+	return hashset.FromIterable[int](hash.Num[int](), s)
+}
+
+// Size returns the number of elements in the set.
+func (s Set) Size() int {
+	if s.root == nil {
+		return 0
+	}
+	return s.root.size
+}
+
+// Empty checks whether the set has no elements.
+func (s Set) Empty() bool {
+	return s.root == nil
+}
+
+// Contains checks whether the given element is in the set.
+func (s Set) Contains(x int) bool {
+	return s.root.contains(toKey(x))
+}
+
+// Add returns a set that additionally contains the given elements.
+func (s Set) Add(elems ...int) Set {
+	root := s.root
+	for _, x := range elems {
+		k := toKey(x)
+		root = root.insertLeaf(leafPrefix(k), bitOf(k))
+	}
+	return Set{root}
+}
+
+// Remove returns a set without the given elements.
+func (s Set) Remove(elems ...int) Set {
+	root := s.root
+	for _, x := range elems {
+		k := toKey(x)
+		root = root.removeLeaf(leafPrefix(k), bitOf(k))
+	}
+	return Set{root}
+}
+
+// Union returns a set containing the elements of both sets.
+func (s Set) Union(other Set) Set {
+	return Set{union(s.root, other.root)}
+}
+
+// Intersect returns a set containing the elements that are in both sets.
+func (s Set) Intersect(other Set) Set {
+	return Set{intersect(s.root, other.root)}
+}
+
+// Minus returns a set containing the elements of this set that are not in the other set.
+func (s Set) Minus(other Set) Set {
+	return Set{minus(s.root, other.root)}
+}
+
+// IsSubsetOf checks whether all elements of this set are in the other set.
+func (s Set) IsSubsetOf(other Set) bool {
+	return s.Size() <= other.Size() && minus(s.root, other.root) == nil
+}
+
+// Disjoint checks whether the sets have no elements in common.
+func (s Set) Disjoint(other Set) bool {
+	return intersect(s.root, other.root) == nil
+}
+
+// Equal checks whether both sets contain the same elements.
+func (s Set) Equal(other Set) bool {
+	return s.root.equal(other.root)
+}
+
+// Rank returns the number of elements in the set that are smaller than x.
+func (s Set) Rank(x int) int {
+	return s.root.rank(toKey(x))
+}
+
+// Select returns the element at the given position in ascending order, starting at 0.
+// Returns false if the index is out of range.
+func (s Set) Select(i int) (int, bool) {
+	if i < 0 || i >= s.Size() {
+		return 0, false
+	}
+	return fromKey(s.root.selectKey(i)), true
+}
+
+// Min returns the smallest element or false if the set is empty.
+func (s Set) Min() (int, bool) {
+	return s.Select(0)
+}
+
+// Max returns the largest element or false if the set is empty.
+func (s Set) Max() (int, bool) {
+	return s.Select(s.Size() - 1)
+}
+
+// Iterator returns the elements in ascending order.
+func (s Set) Iterator() iterable.Iterator[int] {
+	it := s.root.iterator()
+	return iterable.Fun[int](func() (int, bool) {
+		k, ok := it.Next()
+		return fromKey(k), ok
+	})
+}
+
+// Filter returns a set with the elements that satisfy the predicate.
+func (s Set) Filter(pred func(int) bool) Set {
+	res := s
+	for it := s.Iterator(); ; {
+		x, ok := it.Next()
+		if !ok {
+			return res
+		}
+		if !pred(x) {
+			res = res.Remove(x)
+		}
+	}
+}
+
+func (s Set) String() string {
+	return iterable.String[int](s)
+}
@@ -0,0 +1,125 @@
+package intset
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+// elements are mostly from a small range, so that leaves are shared, but include extreme values
+func genElem() *rapid.Generator {
+	return rapid.OneOf(
+		rapid.IntRange(-200, 200),
+		rapid.SampledFrom([]int{math.MinInt64, math.MinInt64 + 1, math.MaxInt64, math.MaxInt64 - 63, 1 << 40}))
+}
+
+func genSet() *rapid.Generator {
+	return rapid.Custom(func(t *rapid.T) []int {
+		return rapid.SliceOf(genElem()).Draw(t, "elems").([]int)
+	})
+}
+
+func checkSet(t *rapid.T, s Set, model map[int]bool) {
+	require.NoError(t, s.root.checkInvariant())
+	sorted := []int{}
+	for x := range model {
+		sorted = append(sorted, x)
+	}
+	sort.Ints(sorted)
+	require.Equal(t, len(sorted), s.Size())
+	require.Equal(t, sorted, append([]int{}, iterable.ToSlice[int](s)...))
+	for i, x := range sorted {
+		require.True(t, s.Contains(x))
+		require.Equal(t, i, s.Rank(x))
+		y, ok := s.Select(i)
+		require.True(t, ok)
+		require.Equal(t, x, y)
+	}
+	_, ok := s.Select(len(sorted))
+	require.False(t, ok)
+}
+
+func toModel(xs []int) map[int]bool {
+	m := make(map[int]bool)
+	for _, x := range xs {
+		m[x] = true
+	}
+	return m
+}
+
+func TestIntSetOperations(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		xs := genSet().Draw(t, "xs").([]int)
+		ys := genSet().Draw(t, "ys").([]int)
+		a, b := New(xs...), New(ys...)
+		ma, mb := toModel(xs), toModel(ys)
+		checkSet(t, a, ma)
+		checkSet(t, b, mb)
+
+		union := make(map[int]bool)
+		inter := make(map[int]bool)
+		diff := make(map[int]bool)
+		for x := range ma {
+			union[x] = true
+			if mb[x] {
+				inter[x] = true
+			} else {
+				diff[x] = true
+			}
+		}
+		for x := range mb {
+			union[x] = true
+		}
+		checkSet(t, a.Union(b), union)
+		checkSet(t, a.Intersect(b), inter)
+		checkSet(t, a.Minus(b), diff)
+		require.Equal(t, len(diff) == 0, a.IsSubsetOf(b))
+		require.Equal(t, len(inter) == 0, a.Disjoint(b))
+		require.Equal(t, len(diff) == 0 && len(union) == len(ma), a.Equal(b))
+
+		removed := a.Remove(ys...)
+		checkSet(t, removed, diff)
+		require.True(t, removed.Equal(a.Minus(b)))
+
+		x := genElem().Draw(t, "x").(int)
+		rank := 0
+		for y := range ma {
+			if y < x {
+				rank++
+			}
+		}
+		require.Equal(t, rank, a.Rank(x))
+	})
+}
+
+func TestIntSetSharing(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		xs := genSet().Draw(t, "xs").([]int)
+		a := New(xs...)
+		b := a.Remove(genElem().Draw(t, "x").(int))
+		require.Same(t, a.root, a.Union(b).root)
+		require.Same(t, b.root, a.Intersect(b).root)
+		require.Same(t, a.root, a.Add(xs...).root)
+		require.Same(t, a.root, a.Minus(New()).root)
+	})
+}
+
+func TestRange(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		start := genElem().Draw(t, "start").(int)
+		n := rapid.IntRange(0, 300).Draw(t, "n").(int)
+		end := start + n
+		if end < start {
+			end = math.MaxInt64
+		}
+		model := make(map[int]bool)
+		for x := start; x < end; x++ {
+			model[x] = true
+		}
+		checkSet(t, Range(start, end), model)
+	})
+}
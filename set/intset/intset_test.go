@@ -0,0 +1,43 @@
+package intset_test
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/set/hashset"
+	"github.com/peterzeller/go-fun/set/intset"
+)
+
+func ExampleSet() {
+	a := intset.New(5, 1, 3, 100, -7)
+	b := intset.Range(0, 10)
+	fmt.Printf("a = %v\n", a)
+	fmt.Printf("union = %v\n", a.Union(b))
+	fmt.Printf("intersect = %v\n", a.Intersect(b))
+	fmt.Printf("minus = %v\n", a.Minus(b))
+	// output:
+	// a = [-7, 1, 3, 5, 100]
+	// union = [-7, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 100]
+	// intersect = [1, 3, 5]
+	// minus = [-7, 100]
+}
+
+func ExampleSet_Rank() {
+	s := intset.New(10, 20, 30, 40)
+	fmt.Printf("rank(25) = %v\n", s.Rank(25))
+	x, ok := s.Select(2)
+	fmt.Printf("select(2) = %v, %v\n", x, ok)
+	// output:
+	// rank(25) = 2
+	// select(2) = 30, true
+}
+
+func ExampleFromHashSet() {
+	h := hashset.New(hash.Num[int](), 3, 1, 2)
+	s := intset.FromHashSet(h)
+	fmt.Printf("%v\n", s)
+	fmt.Printf("%v\n", s.ToHashSet().Equal(h))
+	// output:
+	// [1, 2, 3]
+	// true
+}
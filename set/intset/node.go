@@ -0,0 +1,490 @@
+package intset
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/peterzeller/go-fun/iterable"
+)
+
+// node in the Patricia trie.
+// A leaf has no children and stores the elements with keys prefix ... prefix+63 in the bitmap.
+// A branch stores the common prefix of all keys and the branching bit in mask.
+// Keys with the branching bit set are in the right subtree, all others in the left subtree.
+type node struct {
+	prefix uint64
+	mask   uint64
+	bitmap uint64
+	left   *node
+	right  *node
+	size   int
+}
+
+const leafBits = 64
+
+// toKey maps integers to unsigned keys, such that the order is preserved
+func toKey(x int) uint64 {
+	return uint64(x) ^ (1 << 63)
+}
+
+func fromKey(k uint64) int {
+	return int(k ^ (1 << 63))
+}
+
+func leafPrefix(k uint64) uint64 {
+	return k &^ (leafBits - 1)
+}
+
+func bitOf(k uint64) uint64 {
+	return 1 << (k & (leafBits - 1))
+}
+
+func (n *node) isLeaf() bool {
+	return n.left == nil
+}
+
+func newLeaf(prefix, bitmap uint64) *node {
+	if bitmap == 0 {
+		return nil
+	}
+	return &node{prefix: prefix, bitmap: bitmap, size: bits.OnesCount64(bitmap)}
+}
+
+// newBranch creates a branch node, or returns one of the children if the other child is empty
+func newBranch(prefix, mask uint64, left, right *node) *node {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	return &node{prefix: prefix, mask: mask, left: left, right: right, size: left.size + right.size}
+}
+
+// maskPrefix keeps the bits of k above the branching bit m
+func maskPrefix(k, m uint64) uint64 {
+	return k & ^(m | (m - 1))
+}
+
+func matchPrefix(k, prefix, m uint64) bool {
+	return maskPrefix(k, m) == prefix
+}
+
+func isZero(k, m uint64) bool {
+	return k&m == 0
+}
+
+func highestBit(x uint64) uint64 {
+	return 1 << (63 - bits.LeadingZeros64(x))
+}
+
+// link combines two trees with disjoint prefixes
+func link(p1 uint64, t1 *node, p2 uint64, t2 *node) *node {
+	m := highestBit(p1 ^ p2)
+	p := maskPrefix(p1, m)
+	if isZero(p1, m) {
+		return newBranch(p, m, t1, t2)
+	}
+	return newBranch(p, m, t2, t1)
+}
+
+// shorter checks whether the branching bit m1 is above the branching bit m2
+func shorter(m1, m2 uint64) bool {
+	return m1 > m2
+}
+
+func (n *node) contains(k uint64) bool {
+	for n != nil {
+		if n.isLeaf() {
+			return n.prefix == leafPrefix(k) && n.bitmap&bitOf(k) != 0
+		}
+		if !matchPrefix(k, n.prefix, n.mask) {
+			return false
+		}
+		if isZero(k, n.mask) {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return false
+}
+
+// insertLeaf adds all elements of the bitmap with the given leaf prefix.
+// Returns the original node if nothing changes.
+func (n *node) insertLeaf(prefix, bitmap uint64) *node {
+	if n == nil {
+		return newLeaf(prefix, bitmap)
+	}
+	if n.isLeaf() {
+		if n.prefix == prefix {
+			if n.bitmap|bitmap == n.bitmap {
+				return n
+			}
+			return newLeaf(prefix, n.bitmap|bitmap)
+		}
+		return link(prefix, newLeaf(prefix, bitmap), n.prefix, n)
+	}
+	if !matchPrefix(prefix, n.prefix, n.mask) {
+		return link(prefix, newLeaf(prefix, bitmap), n.prefix, n)
+	}
+	if isZero(prefix, n.mask) {
+		left := n.left.insertLeaf(prefix, bitmap)
+		if left == n.left {
+			return n
+		}
+		return newBranch(n.prefix, n.mask, left, n.right)
+	}
+	right := n.right.insertLeaf(prefix, bitmap)
+	if right == n.right {
+		return n
+	}
+	return newBranch(n.prefix, n.mask, n.left, right)
+}
+
+// removeLeaf removes all elements of the bitmap with the given leaf prefix.
+// Returns the original node if nothing changes.
+func (n *node) removeLeaf(prefix, bitmap uint64) *node {
+	if n == nil {
+		return nil
+	}
+	if n.isLeaf() {
+		if n.prefix != prefix || n.bitmap&bitmap == 0 {
+			return n
+		}
+		return newLeaf(prefix, n.bitmap&^bitmap)
+	}
+	if !matchPrefix(prefix, n.prefix, n.mask) {
+		return n
+	}
+	if isZero(prefix, n.mask) {
+		left := n.left.removeLeaf(prefix, bitmap)
+		if left == n.left {
+			return n
+		}
+		return newBranch(n.prefix, n.mask, left, n.right)
+	}
+	right := n.right.removeLeaf(prefix, bitmap)
+	if right == n.right {
+		return n
+	}
+	return newBranch(n.prefix, n.mask, n.left, right)
+}
+
+// lookupLeaf returns the bitmap for the given leaf prefix
+func (n *node) lookupLeaf(prefix uint64) uint64 {
+	for n != nil {
+		if n.isLeaf() {
+			if n.prefix == prefix {
+				return n.bitmap
+			}
+			return 0
+		}
+		if !matchPrefix(prefix, n.prefix, n.mask) {
+			return 0
+		}
+		if isZero(prefix, n.mask) {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return 0
+}
+
+func union(a, b *node) *node {
+	switch {
+	case a == nil:
+		return b
+	case b == nil || a == b:
+		return a
+	case b.isLeaf():
+		return a.insertLeaf(b.prefix, b.bitmap)
+	case a.isLeaf():
+		return b.insertLeaf(a.prefix, a.bitmap)
+	case shorter(a.mask, b.mask):
+		if !matchPrefix(b.prefix, a.prefix, a.mask) {
+			return link(a.prefix, a, b.prefix, b)
+		}
+		if isZero(b.prefix, a.mask) {
+			left := union(a.left, b)
+			if left == a.left {
+				return a
+			}
+			return newBranch(a.prefix, a.mask, left, a.right)
+		}
+		right := union(a.right, b)
+		if right == a.right {
+			return a
+		}
+		return newBranch(a.prefix, a.mask, a.left, right)
+	case shorter(b.mask, a.mask):
+		if !matchPrefix(a.prefix, b.prefix, b.mask) {
+			return link(a.prefix, a, b.prefix, b)
+		}
+		if isZero(a.prefix, b.mask) {
+			left := union(a, b.left)
+			if left == b.left {
+				return b
+			}
+			return newBranch(b.prefix, b.mask, left, b.right)
+		}
+		right := union(a, b.right)
+		if right == b.right {
+			return b
+		}
+		return newBranch(b.prefix, b.mask, b.left, right)
+	case a.prefix == b.prefix:
+		left := union(a.left, b.left)
+		right := union(a.right, b.right)
+		if left == a.left && right == a.right {
+			return a
+		}
+		if left == b.left && right == b.right {
+			return b
+		}
+		return newBranch(a.prefix, a.mask, left, right)
+	default:
+		return link(a.prefix, a, b.prefix, b)
+	}
+}
+
+func intersect(a, b *node) *node {
+	switch {
+	case a == nil || b == nil:
+		return nil
+	case a == b:
+		return a
+	case a.isLeaf():
+		bm := a.bitmap & b.lookupLeaf(a.prefix)
+		if bm == a.bitmap {
+			return a
+		}
+		if b.isLeaf() && bm == b.bitmap {
+			return b
+		}
+		return newLeaf(a.prefix, bm)
+	case b.isLeaf():
+		bm := b.bitmap & a.lookupLeaf(b.prefix)
+		if bm == b.bitmap {
+			return b
+		}
+		return newLeaf(b.prefix, bm)
+	case shorter(a.mask, b.mask):
+		if !matchPrefix(b.prefix, a.prefix, a.mask) {
+			return nil
+		}
+		if isZero(b.prefix, a.mask) {
+			return intersect(a.left, b)
+		}
+		return intersect(a.right, b)
+	case shorter(b.mask, a.mask):
+		if !matchPrefix(a.prefix, b.prefix, b.mask) {
+			return nil
+		}
+		if isZero(a.prefix, b.mask) {
+			return intersect(a, b.left)
+		}
+		return intersect(a, b.right)
+	case a.prefix == b.prefix:
+		left := intersect(a.left, b.left)
+		right := intersect(a.right, b.right)
+		if left == a.left && right == a.right {
+			return a
+		}
+		if left == b.left && right == b.right {
+			return b
+		}
+		return newBranch(a.prefix, a.mask, left, right)
+	default:
+		return nil
+	}
+}
+
+func minus(a, b *node) *node {
+	switch {
+	case a == nil || a == b:
+		return nil
+	case b == nil:
+		return a
+	case b.isLeaf():
+		return a.removeLeaf(b.prefix, b.bitmap)
+	case a.isLeaf():
+		bm := a.bitmap &^ b.lookupLeaf(a.prefix)
+		if bm == a.bitmap {
+			return a
+		}
+		return newLeaf(a.prefix, bm)
+	case shorter(a.mask, b.mask):
+		if !matchPrefix(b.prefix, a.prefix, a.mask) {
+			return a
+		}
+		if isZero(b.prefix, a.mask) {
+			left := minus(a.left, b)
+			if left == a.left {
+				return a
+			}
+			return newBranch(a.prefix, a.mask, left, a.right)
+		}
+		right := minus(a.right, b)
+		if right == a.right {
+			return a
+		}
+		return newBranch(a.prefix, a.mask, a.left, right)
+	case shorter(b.mask, a.mask):
+		if !matchPrefix(a.prefix, b.prefix, b.mask) {
+			return a
+		}
+		if isZero(a.prefix, b.mask) {
+			return minus(a, b.left)
+		}
+		return minus(a, b.right)
+	case a.prefix == b.prefix:
+		left := minus(a.left, b.left)
+		right := minus(a.right, b.right)
+		if left == a.left && right == a.right {
+			return a
+		}
+		return newBranch(a.prefix, a.mask, left, right)
+	default:
+		return a
+	}
+}
+
+// equal checks structural equality, which coincides with set equality since the trie representation is unique
+func (n *node) equal(other *node) bool {
+	if n == other {
+		return true
+	}
+	if n == nil || other == nil {
+		return false
+	}
+	if n.prefix != other.prefix || n.mask != other.mask || n.bitmap != other.bitmap || n.size != other.size {
+		return false
+	}
+	if n.isLeaf() {
+		return other.isLeaf()
+	}
+	return !other.isLeaf() && n.left.equal(other.left) && n.right.equal(other.right)
+}
+
+// rank returns the number of keys smaller than k
+func (n *node) rank(k uint64) int {
+	res := 0
+	for n != nil {
+		if n.isLeaf() {
+			lp := leafPrefix(k)
+			switch {
+			case lp == n.prefix:
+				res += bits.OnesCount64(n.bitmap & (bitOf(k) - 1))
+			case lp > n.prefix:
+				res += n.size
+			}
+			return res
+		}
+		if !matchPrefix(k, n.prefix, n.mask) {
+			if k > n.prefix {
+				res += n.size
+			}
+			return res
+		}
+		if isZero(k, n.mask) {
+			n = n.left
+		} else {
+			res += n.left.size
+			n = n.right
+		}
+	}
+	return res
+}
+
+// selectKey returns the key with the given rank, where 0 <= i < n.size
+func (n *node) selectKey(i int) uint64 {
+	for !n.isLeaf() {
+		if i < n.left.size {
+			n = n.left
+		} else {
+			i -= n.left.size
+			n = n.right
+		}
+	}
+	bm := n.bitmap
+	for ; i > 0; i-- {
+		bm &= bm - 1
+	}
+	return n.prefix + uint64(bits.TrailingZeros64(bm))
+}
+
+// iterator returns the keys in ascending order
+func (n *node) iterator() iterable.Iterator[uint64] {
+	var stack []*node
+	if n != nil {
+		stack = append(stack, n)
+	}
+	var leaf *node
+	var bm uint64
+	return iterable.Fun[uint64](func() (uint64, bool) {
+		for bm == 0 {
+			if len(stack) == 0 {
+				return 0, false
+			}
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for !n.isLeaf() {
+				stack = append(stack, n.right)
+				n = n.left
+			}
+			leaf = n
+			bm = n.bitmap
+		}
+		k := leaf.prefix + uint64(bits.TrailingZeros64(bm))
+		bm &= bm - 1
+		return k, true
+	})
+}
+
+// checkInvariant checks the Patricia trie structure and cached sizes
+func (n *node) checkInvariant() error {
+	if n == nil {
+		return nil
+	}
+	if n.isLeaf() {
+		if n.right != nil {
+			return fmt.Errorf("leaf with right child")
+		}
+		if n.bitmap == 0 {
+			return fmt.Errorf("empty leaf")
+		}
+		if n.prefix != leafPrefix(n.prefix) {
+			return fmt.Errorf("leaf prefix %x is not aligned", n.prefix)
+		}
+		if n.size != bits.OnesCount64(n.bitmap) {
+			return fmt.Errorf("wrong leaf size")
+		}
+		return nil
+	}
+	if n.right == nil {
+		return fmt.Errorf("branch with only one child")
+	}
+	if bits.OnesCount64(n.mask) != 1 || n.mask < leafBits {
+		return fmt.Errorf("invalid mask %x", n.mask)
+	}
+	for _, child := range []*node{n.left, n.right} {
+		if !matchPrefix(child.prefix, n.prefix, n.mask) {
+			return fmt.Errorf("child prefix %x does not match %x/%x", child.prefix, n.prefix, n.mask)
+		}
+		if !child.isLeaf() && !shorter(n.mask, child.mask) {
+			return fmt.Errorf("child mask %x not below %x", child.mask, n.mask)
+		}
+		if err := child.checkInvariant(); err != nil {
+			return err
+		}
+	}
+	if !isZero(n.left.prefix, n.mask) || isZero(n.right.prefix, n.mask) {
+		return fmt.Errorf("children on the wrong side of %x", n.mask)
+	}
+	if n.size != n.left.size+n.right.size {
+		return fmt.Errorf("wrong branch size")
+	}
+	return nil
+}
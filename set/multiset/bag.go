@@ -0,0 +1,266 @@
+package multiset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/dict/hashdict"
+	"github.com/peterzeller/go-fun/equality"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// Bag is a multiset, in which each element has a multiplicity.
+// Only elements with a positive multiplicity are stored.
+type Bag[T any] struct {
+	counts hashdict.Dict[T, int]
+	// sum of all multiplicities
+	size int
+}
+
+var _ equality.Equal[Bag[int]] = Bag[int]{}
+
+// New creates a new bag containing the given elements.
+// Elements that appear multiple times are added with the respective multiplicity.
+func New[T any](eq hash.EqHash[T], elems ...T) Bag[T] {
+	res := Bag[T]{counts: hashdict.New[T, int](eq)}
+	for _, e := range elems {
+		res = res.Add(e)
+	}
+	return res
+}
+
+// FromIterable creates a new bag containing the elements of the iterable
+func FromIterable[T any](eq hash.EqHash[T], elems iterable.Iterable[T]) Bag[T] {
+	res := New(eq)
+	for it := elems.Iterator(); ; {
+		x, ok := it.Next()
+		if !ok {
+			break
+		}
+		res = res.Add(x)
+	}
+	return res
+}
+
+// EqHash returns the hash instance used in the bag
+func (b Bag[T]) EqHash() hash.EqHash[T] {
+	return b.counts.KeyEq()
+}
+
+// Add an element once
+func (b Bag[T]) Add(elem T) Bag[T] {
+	return b.AddN(elem, 1)
+}
+
+// AddN adds an element with the given multiplicity.
+// If n is negative, the element is removed -n times.
+func (b Bag[T]) AddN(elem T, n int) Bag[T] {
+	if n == 0 {
+		return b
+	}
+	diff := 0
+	newCounts := b.counts.Update(elem, func(old int, exists bool) (int, bool) {
+		newCount := old + n
+		if newCount < 0 {
+			newCount = 0
+		}
+		diff = newCount - old
+		return newCount, newCount > 0
+	})
+	if diff == 0 {
+		return b
+	}
+	return Bag[T]{counts: newCounts, size: b.size + diff}
+}
+
+// Remove one occurrence of the element
+func (b Bag[T]) Remove(elem T) Bag[T] {
+	return b.AddN(elem, -1)
+}
+
+// RemoveN removes the element n times.
+// If the multiplicity of the element is smaller than n, the element is removed completely.
+func (b Bag[T]) RemoveN(elem T, n int) Bag[T] {
+	return b.AddN(elem, -n)
+}
+
+// RemoveAll removes all occurrences of the element
+func (b Bag[T]) RemoveAll(elem T) Bag[T] {
+	return b.SetCount(elem, 0)
+}
+
+// SetCount sets the multiplicity of the element.
+func (b Bag[T]) SetCount(elem T, n int) Bag[T] {
+	return b.AddN(elem, n-b.Count(elem))
+}
+
+// Count returns the multiplicity of the element
+func (b Bag[T]) Count(elem T) int {
+	return b.counts.GetOrZero(elem)
+}
+
+// Contains checks whether the element appears at least once in the bag
+func (b Bag[T]) Contains(elem T) bool {
+	return b.counts.ContainsKey(elem)
+}
+
+// Size returns the number of elements in the bag, counting multiplicities
+func (b Bag[T]) Size() int {
+	return b.size
+}
+
+// DistinctSize returns the number of distinct elements in the bag
+func (b Bag[T]) DistinctSize() int {
+	return b.counts.Size()
+}
+
+// Distinct returns the distinct elements in the bag
+func (b Bag[T]) Distinct() iterable.Iterable[T] {
+	return b.counts.Keys()
+}
+
+// Counts returns the distinct elements together with their multiplicities
+func (b Bag[T]) Counts() hashdict.Dict[T, int] {
+	return b.counts
+}
+
+// Iterator over the bag.
+// Each element is returned as often as its multiplicity.
+func (b Bag[T]) Iterator() iterable.Iterator[T] {
+	it := b.counts.Iterator()
+	var current T
+	remaining := 0
+	return iterable.Fun[T](func() (T, bool) {
+		for remaining == 0 {
+			e, ok := it.Next()
+			if !ok {
+				return zero.Value[T](), false
+			}
+			current = e.Key
+			remaining = e.Value
+		}
+		remaining--
+		return current, true
+	})
+}
+
+// Union returns a bag where each element has the maximum multiplicity of both bags
+func (b Bag[T]) Union(other Bag[T]) Bag[T] {
+	return b.merge(other, func(x, y int) int {
+		if x > y {
+			return x
+		}
+		return y
+	}, true)
+}
+
+// Intersect returns a bag where each element has the minimum multiplicity of both bags
+func (b Bag[T]) Intersect(other Bag[T]) Bag[T] {
+	return b.merge(other, func(x, y int) int {
+		if x < y {
+			return x
+		}
+		return y
+	}, false)
+}
+
+// Sum returns a bag where the multiplicities of both bags are added
+func (b Bag[T]) Sum(other Bag[T]) Bag[T] {
+	return b.merge(other, func(x, y int) int {
+		return x + y
+	}, true)
+}
+
+// Minus returns a bag where the multiplicities of the other bag are subtracted
+func (b Bag[T]) Minus(other Bag[T]) Bag[T] {
+	// entries only in this bag are kept, so only entries in both bags change the size
+	size := b.size
+	res := b.counts.MergeAll(other.counts, hashdict.MergeOpts[T, int, int, int]{
+		Left: func(k T, x int) (int, bool) { return x, true },
+		Both: func(k T, x, y int) (int, bool) {
+			if x > y {
+				size -= y
+				return x - y, true
+			}
+			size -= x
+			return 0, false
+		},
+		LeftUnchanged: true,
+	})
+	return Bag[T]{counts: res, size: size}
+}
+
+// merge combines the counts of both bags.
+// If keepSingle is true, elements appearing only in one bag are kept with their multiplicity.
+// Otherwise, they are removed.
+func (b Bag[T]) merge(other Bag[T], f func(x, y int) int, keepSingle bool) Bag[T] {
+	// the Left and Right callbacks are not called for unchanged parts,
+	// so the size starts with the kept entries and is updated for the entries in both bags
+	size := 0
+	if keepSingle {
+		size = b.size + other.size
+	}
+	opts := hashdict.MergeOpts[T, int, int, int]{
+		Both: func(k T, x, y int) (int, bool) {
+			if keepSingle {
+				size -= x + y
+			}
+			r := f(x, y)
+			if r <= 0 {
+				return r, false
+			}
+			size += r
+			return r, true
+		},
+	}
+	if keepSingle {
+		keep := func(k T, x int) (int, bool) { return x, true }
+		opts.Left = keep
+		opts.Right = keep
+		opts.LeftUnchanged = true
+		opts.RightUnchanged = true
+	}
+	return Bag[T]{counts: b.counts.MergeAll(other.counts, opts), size: size}
+}
+
+// IsSubsetOf checks whether each element appears in the other bag at least as often as in this bag
+func (b Bag[T]) IsSubsetOf(other Bag[T]) bool {
+	if b.size > other.size {
+		return false
+	}
+	for it := iterable.Start[dict.Entry[T, int]](b.counts); it.HasNext(); it.Next() {
+		if other.Count(it.Current().Key) < it.Current().Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal checks whether both bags contain the same elements with the same multiplicities
+func (b Bag[T]) Equal(other Bag[T]) bool {
+	return b.size == other.size && b.DistinctSize() == other.DistinctSize() && b.IsSubsetOf(other)
+}
+
+// String representation of the bag
+func (b Bag[T]) String() string {
+	var res strings.Builder
+	res.WriteString("[")
+	first := true
+	for it := iterable.Start[dict.Entry[T, int]](b.counts); it.HasNext(); it.Next() {
+		if !first {
+			res.WriteString(", ")
+		}
+		e := it.Current()
+		if e.Value == 1 {
+			res.WriteString(fmt.Sprintf("%+v", e.Key))
+		} else {
+			res.WriteString(fmt.Sprintf("%+v x%d", e.Key, e.Value))
+		}
+		first = false
+	}
+	res.WriteString("]")
+	return res.String()
+}
@@ -0,0 +1,114 @@
+package multiset_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/set/multiset"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleBag_Add() {
+	b := multiset.New(hash.String(), "a", "b", "a")
+	b = b.Add("c").AddN("b", 2)
+	fmt.Printf("b = %v\n", b)
+	fmt.Printf("count(b) = %d, size = %d\n", b.Count("b"), b.Size())
+	// output:
+	// b = [b x3, a x2, c]
+	// count(b) = 3, size = 6
+}
+
+func ExampleBag_Remove() {
+	b := multiset.New(hash.String(), "a", "b", "a")
+	b = b.Remove("a").Remove("b").Remove("x")
+	fmt.Printf("b = %v\n", b)
+	// output:
+	// b = [a]
+}
+
+func ExampleBag_Union() {
+	b1 := multiset.New(hash.String(), "a", "a", "b")
+	b2 := multiset.New(hash.String(), "a", "b", "b", "c")
+	fmt.Printf("union = %v\n", b1.Union(b2))
+	fmt.Printf("intersection = %v\n", b1.Intersect(b2))
+	fmt.Printf("sum = %v\n", b1.Sum(b2))
+	fmt.Printf("minus = %v\n", b1.Minus(b2))
+	// output:
+	// union = [b x2, a x2, c]
+	// intersection = [b, a]
+	// sum = [b x3, a x3, c]
+	// minus = [a]
+}
+
+func ExampleBag_Iterator() {
+	b := multiset.New(hash.Num[int](), 1, 2, 1)
+	fmt.Printf("%v\n", iterable.ToSlice[int](b))
+	// output: [1 1 2]
+}
+
+func TestBagModel(t *testing.T) {
+	randomBag := func(r *rand.Rand) (multiset.Bag[int], map[int]int) {
+		b := multiset.New(hash.Num[int]())
+		model := map[int]int{}
+		for i := r.Intn(50); i > 0; i-- {
+			x := r.Intn(10)
+			n := r.Intn(4)
+			b = b.AddN(x, n)
+			model[x] += n
+		}
+		return b, model
+	}
+	check := func(b multiset.Bag[int], model map[int]int) {
+		size := 0
+		for x := 0; x < 10; x++ {
+			require.Equal(t, model[x], b.Count(x), "count of %d in %v", x, b)
+			size += model[x]
+		}
+		require.Equal(t, size, b.Size())
+		require.Equal(t, size, iterable.Length[int](b))
+	}
+	for seed := int64(0); seed < 50; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		a, am := randomBag(r)
+		b, bm := randomBag(r)
+		check(a, am)
+		check(b, bm)
+		union, inter, sum, minus := map[int]int{}, map[int]int{}, map[int]int{}, map[int]int{}
+		for x := 0; x < 10; x++ {
+			union[x] = am[x]
+			if bm[x] > am[x] {
+				union[x] = bm[x]
+			}
+			inter[x] = am[x]
+			if bm[x] < am[x] {
+				inter[x] = bm[x]
+			}
+			sum[x] = am[x] + bm[x]
+			if am[x] > bm[x] {
+				minus[x] = am[x] - bm[x]
+			}
+		}
+		check(a.Union(b), union)
+		check(a.Intersect(b), inter)
+		check(a.Sum(b), sum)
+		check(a.Minus(b), minus)
+		require.True(t, a.Intersect(b).IsSubsetOf(a))
+		require.True(t, a.IsSubsetOf(a.Union(b)))
+		require.True(t, a.Union(b).Equal(b.Union(a)))
+		// bags that share parts of their structure
+		double := map[int]int{}
+		for x, n := range am {
+			double[x] = 2 * n
+		}
+		check(a.Union(a), am)
+		check(a.Sum(a), double)
+		check(a.Minus(a), map[int]int{})
+		check(a.Add(3).Intersect(a), am)
+
+		x := r.Intn(10)
+		check(a.RemoveAll(x), func() map[int]int { am[x] = 0; return am }())
+	}
+}
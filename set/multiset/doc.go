@@ -0,0 +1,5 @@
+/*
+Package multiset implements an immutable multiset (bag), which can contain elements multiple times.
+It is implemented on top of hashdict by storing the multiplicity of each element.
+*/
+package multiset
@@ -0,0 +1,11 @@
+/*
+Package trie implements immutable prefix trees.
+
+Trie is keyed by strings and iterates its entries in sorted order.
+SliceTrie is keyed by slices of an arbitrary element type with a hash.EqHash instance.
+
+Both support prefix queries: WithPrefix returns all entries starting with a given prefix and
+LongestPrefixOf finds the longest key that is a prefix of a given key.
+Updates create new versions of the trie that share unchanged subtrees with the old version.
+*/
+package trie
@@ -0,0 +1,294 @@
+package trie
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/dict/hashdict"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/zero"
+)
+
+// SliceTrie is an immutable trie with keys of type []K.
+// The children of each node are stored in a hashdict.Dict, so K only needs a hash.EqHash instance.
+type SliceTrie[K, V any] struct {
+	// root is nil for the empty trie
+	root *node[K, V]
+	eq   hash.EqHash[K]
+}
+
+// node in the trie.
+// Every node except for the root of the empty trie contains at least one value in its subtree.
+type node[K, V any] struct {
+	value    V
+	hasValue bool
+	children hashdict.Dict[K, *node[K, V]]
+	// size is the number of values in this subtree
+	size int
+}
+
+// NewSlice creates a new trie with keys of type []K from the given entries.
+func NewSlice[K, V any](eq hash.EqHash[K], entries ...dict.Entry[[]K, V]) SliceTrie[K, V] {
+	t := SliceTrie[K, V]{eq: eq}
+	for _, e := range entries {
+		t = t.Set(e.Key, e.Value)
+	}
+	return t
+}
+
+// KeyEq returns the equality and hash function used for the key elements.
+func (t SliceTrie[K, V]) KeyEq() hash.EqHash[K] {
+	return t.eq
+}
+
+// Size returns the number of entries in the trie.
+func (t SliceTrie[K, V]) Size() int {
+	if t.root == nil {
+		return 0
+	}
+	return t.root.size
+}
+
+// Get returns the value for the given key or false if the key is not in the trie.
+func (t SliceTrie[K, V]) Get(key []K) (V, bool) {
+	n := t.find(key)
+	if n == nil || !n.hasValue {
+		return zero.Value[V](), false
+	}
+	return n.value, true
+}
+
+// GetOrZero returns the value for the given key or the zero value if the key is not in the trie.
+func (t SliceTrie[K, V]) GetOrZero(key []K) V {
+	v, _ := t.Get(key)
+	return v
+}
+
+// ContainsKey checks whether the trie contains the given key.
+func (t SliceTrie[K, V]) ContainsKey(key []K) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// find returns the node for the given key or nil if there is no such node.
+func (t SliceTrie[K, V]) find(key []K) *node[K, V] {
+	n := t.root
+	for _, k := range key {
+		if n == nil {
+			return nil
+		}
+		n, _ = n.children.Get(k)
+	}
+	return n
+}
+
+// Set returns a new trie where the given key is mapped to the given value.
+func (t SliceTrie[K, V]) Set(key []K, value V) SliceTrie[K, V] {
+	root, _ := t.root.set(key, value, t.eq)
+	return SliceTrie[K, V]{root: root, eq: t.eq}
+}
+
+func (n *node[K, V]) set(key []K, value V, eq hash.EqHash[K]) (*node[K, V], bool) {
+	var res node[K, V]
+	if n != nil {
+		res = *n
+	} else {
+		res.children = hashdict.New[K, *node[K, V]](eq)
+	}
+	var added bool
+	if len(key) == 0 {
+		added = !res.hasValue
+		res.value = value
+		res.hasValue = true
+	} else {
+		child, _ := res.children.Get(key[0])
+		var newChild *node[K, V]
+		newChild, added = child.set(key[1:], value, eq)
+		res.children = res.children.Set(key[0], newChild)
+	}
+	if added {
+		res.size++
+	}
+	return &res, added
+}
+
+// Remove returns a new trie without the given key.
+// If the key is not in the trie, the trie is returned unchanged.
+func (t SliceTrie[K, V]) Remove(key []K) SliceTrie[K, V] {
+	root, removed := t.root.remove(key)
+	if !removed {
+		return t
+	}
+	return SliceTrie[K, V]{root: root, eq: t.eq}
+}
+
+func (n *node[K, V]) remove(key []K) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	if len(key) == 0 {
+		if !n.hasValue {
+			return n, false
+		}
+		if n.size == 1 {
+			return nil, true
+		}
+		res := *n
+		res.value = zero.Value[V]()
+		res.hasValue = false
+		res.size--
+		return &res, true
+	}
+	child, ok := n.children.Get(key[0])
+	if !ok {
+		return n, false
+	}
+	newChild, removed := child.remove(key[1:])
+	if !removed {
+		return n, false
+	}
+	if n.size == 1 {
+		return nil, true
+	}
+	res := *n
+	if newChild == nil {
+		res.children = res.children.Remove(key[0])
+	} else {
+		res.children = res.children.Set(key[0], newChild)
+	}
+	res.size--
+	return &res, true
+}
+
+// WithPrefix returns a trie containing all entries where the key starts with the given prefix.
+// The result shares the subtree for the prefix with the original trie.
+func (t SliceTrie[K, V]) WithPrefix(prefix []K) SliceTrie[K, V] {
+	n := t.find(prefix)
+	if n == nil {
+		return SliceTrie[K, V]{eq: t.eq}
+	}
+	for i := len(prefix) - 1; i >= 0; i-- {
+		n = &node[K, V]{
+			children: hashdict.New(t.eq, dict.E(prefix[i], n)),
+			size:     n.size,
+		}
+	}
+	return SliceTrie[K, V]{root: n, eq: t.eq}
+}
+
+// LongestPrefixOf returns the entry with the longest key that is a prefix of the given key.
+// Returns false if no key in the trie is a prefix of the given key.
+func (t SliceTrie[K, V]) LongestPrefixOf(key []K) (dict.Entry[[]K, V], bool) {
+	n := t.root
+	found := -1
+	var value V
+	for i := 0; n != nil; i++ {
+		if n.hasValue {
+			found = i
+			value = n.value
+		}
+		if i >= len(key) {
+			break
+		}
+		n, _ = n.children.Get(key[i])
+	}
+	if found < 0 {
+		return zero.Value[dict.Entry[[]K, V]](), false
+	}
+	return dict.E(append([]K{}, key[:found]...), value), true
+}
+
+// Iterator returns the entries of the trie.
+// Each key is returned before all keys that it is a prefix of, but siblings are returned in an unspecified order.
+// Use Sorted to iterate in lexicographic order.
+func (t SliceTrie[K, V]) Iterator() iterable.Iterator[dict.Entry[[]K, V]] {
+	return t.root.iterator(nil)
+}
+
+// Sorted returns the entries of the trie in lexicographic order of the keys,
+// where the key elements are compared with the given less function.
+func (t SliceTrie[K, V]) Sorted(less func(a, b K) bool) iterable.Iterable[dict.Entry[[]K, V]] {
+	return iterable.IterableFun[dict.Entry[[]K, V]](func() iterable.Iterator[dict.Entry[[]K, V]] {
+		return t.root.iterator(less)
+	})
+}
+
+// Keys returns the keys in the trie, in the same order as Iterator.
+func (t SliceTrie[K, V]) Keys() iterable.Iterable[[]K] {
+	return iterable.Map[dict.Entry[[]K, V], []K](t, func(e dict.Entry[[]K, V]) []K { return e.Key })
+}
+
+// Values returns the values in the trie, in the same order as Iterator.
+func (t SliceTrie[K, V]) Values() iterable.Iterable[V] {
+	return iterable.Map[dict.Entry[[]K, V], V](t, func(e dict.Entry[[]K, V]) V { return e.Value })
+}
+
+func (t SliceTrie[K, V]) String() string {
+	return iterable.String[dict.Entry[[]K, V]](t)
+}
+
+// iterator traverses the trie in pre-order.
+// If less is not nil, the children of each node are visited in sorted order.
+func (n *node[K, V]) iterator(less func(a, b K) bool) iterable.Iterator[dict.Entry[[]K, V]] {
+	type item struct {
+		n   *node[K, V]
+		key []K
+	}
+	var stack []item
+	if n != nil {
+		stack = append(stack, item{n, []K{}})
+	}
+	return iterable.Fun[dict.Entry[[]K, V]](func() (dict.Entry[[]K, V], bool) {
+		for len(stack) > 0 {
+			current := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			children := iterable.ToSlice[dict.Entry[K, *node[K, V]]](current.n.children)
+			if less != nil {
+				sort.Slice(children, func(i, j int) bool {
+					return less(children[i].Key, children[j].Key)
+				})
+			}
+			// push in reverse order, so that the first child is visited first
+			for i := len(children) - 1; i >= 0; i-- {
+				key := make([]K, len(current.key)+1)
+				copy(key, current.key)
+				key[len(current.key)] = children[i].Key
+				stack = append(stack, item{children[i].Value, key})
+			}
+			if current.n.hasValue {
+				return dict.E(current.key, current.n.value), true
+			}
+		}
+		return zero.Value[dict.Entry[[]K, V]](), false
+	})
+}
+
+// checkInvariant checks that the cached sizes are correct and that there are no empty subtrees.
+func (n *node[K, V]) checkInvariant() error {
+	if n == nil {
+		return nil
+	}
+	size := 0
+	if n.hasValue {
+		size++
+	}
+	for it := iterable.Start[dict.Entry[K, *node[K, V]]](n.children); it.HasNext(); it.Next() {
+		child := it.Current().Value
+		if child == nil {
+			return fmt.Errorf("nil child for key %v", it.Current().Key)
+		}
+		if err := child.checkInvariant(); err != nil {
+			return err
+		}
+		size += child.size
+	}
+	if size == 0 {
+		return fmt.Errorf("empty subtree")
+	}
+	if size != n.size {
+		return fmt.Errorf("cached size %d but actual size is %d", n.size, size)
+	}
+	return nil
+}
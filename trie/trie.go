@@ -0,0 +1,112 @@
+package trie
+
+import (
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+)
+
+// Trie is an immutable trie with string keys.
+// The entries are iterated in sorted order of the keys.
+// The zero value is an empty trie.
+type Trie[V any] struct {
+	t SliceTrie[byte, V]
+}
+
+var byteEq = hash.Num[byte]()
+
+func byteLess(a, b byte) bool {
+	return a < b
+}
+
+// New creates a new trie with the given entries.
+func New[V any](entries ...dict.Entry[string, V]) Trie[V] {
+	var t Trie[V]
+	for _, e := range entries {
+		t = t.Set(e.Key, e.Value)
+	}
+	return t
+}
+
+// FromIterable creates a new trie from the given entries.
+func FromIterable[V any](entries iterable.Iterable[dict.Entry[string, V]]) Trie[V] {
+	var t Trie[V]
+	for it := iterable.Start(entries); it.HasNext(); it.Next() {
+		t = t.Set(it.Current().Key, it.Current().Value)
+	}
+	return t
+}
+
+func (t Trie[V]) inner() SliceTrie[byte, V] {
+	if t.t.eq == nil {
+		t.t.eq = byteEq
+	}
+	return t.t
+}
+
+// Size returns the number of entries in the trie.
+func (t Trie[V]) Size() int {
+	return t.t.Size()
+}
+
+// Get returns the value for the given key or false if the key is not in the trie.
+func (t Trie[V]) Get(key string) (V, bool) {
+	return t.inner().Get([]byte(key))
+}
+
+// GetOrZero returns the value for the given key or the zero value if the key is not in the trie.
+func (t Trie[V]) GetOrZero(key string) V {
+	return t.inner().GetOrZero([]byte(key))
+}
+
+// ContainsKey checks whether the trie contains the given key.
+func (t Trie[V]) ContainsKey(key string) bool {
+	return t.inner().ContainsKey([]byte(key))
+}
+
+// Set returns a new trie where the given key is mapped to the given value.
+func (t Trie[V]) Set(key string, value V) Trie[V] {
+	return Trie[V]{t.inner().Set([]byte(key), value)}
+}
+
+// Remove returns a new trie without the given key.
+// If the key is not in the trie, the trie is returned unchanged.
+func (t Trie[V]) Remove(key string) Trie[V] {
+	return Trie[V]{t.inner().Remove([]byte(key))}
+}
+
+// WithPrefix returns a trie containing all entries where the key starts with the given prefix.
+// The result shares the subtree for the prefix with the original trie.
+func (t Trie[V]) WithPrefix(prefix string) Trie[V] {
+	return Trie[V]{t.inner().WithPrefix([]byte(prefix))}
+}
+
+// LongestPrefixOf returns the entry with the longest key that is a prefix of the given key.
+// Returns false if no key in the trie is a prefix of the given key.
+func (t Trie[V]) LongestPrefixOf(key string) (dict.Entry[string, V], bool) {
+	e, ok := t.inner().LongestPrefixOf([]byte(key))
+	return dict.E(string(e.Key), e.Value), ok
+}
+
+// Iterator returns the entries of the trie in sorted order of the keys.
+func (t Trie[V]) Iterator() iterable.Iterator[dict.Entry[string, V]] {
+	it := t.t.root.iterator(byteLess)
+	return iterable.Fun[dict.Entry[string, V]](func() (dict.Entry[string, V], bool) {
+		e, ok := it.Next()
+		return dict.E(string(e.Key), e.Value), ok
+	})
+}
+
+// Keys returns the keys in the trie in sorted order.
+func (t Trie[V]) Keys() iterable.Iterable[string] {
+	return iterable.Map[dict.Entry[string, V], string](t, func(e dict.Entry[string, V]) string { return e.Key })
+}
+
+// Values returns the values in the trie in sorted order of the keys.
+func (t Trie[V]) Values() iterable.Iterable[V] {
+	return iterable.Map[dict.Entry[string, V], V](t, func(e dict.Entry[string, V]) V { return e.Value })
+}
+
+func (t Trie[V]) String() string {
+	return iterable.String[dict.Entry[string, V]](t)
+}
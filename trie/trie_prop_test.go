@@ -0,0 +1,99 @@
+package trie
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/stretchr/testify/require"
+	"pgregory.net/rapid"
+)
+
+// keys from a small alphabet, so that many keys share prefixes
+func genKey() *rapid.Generator {
+	return rapid.StringMatching("[abc]{0,4}")
+}
+
+func checkModel(t *rapid.T, tr Trie[int], model map[string]int) {
+	require.NoError(t, tr.t.root.checkInvariant())
+	require.Equal(t, len(model), tr.Size())
+	var keys []string
+	for k := range model {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	expected := []dict.Entry[string, int]{}
+	for _, k := range keys {
+		expected = append(expected, dict.E(k, model[k]))
+	}
+	require.Equal(t, expected, append([]dict.Entry[string, int]{}, iterable.ToSlice[dict.Entry[string, int]](tr)...))
+}
+
+func TestTrieOperations(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		var tr Trie[int]
+		model := make(map[string]int)
+		var versions []Trie[int]
+		var models []map[string]int
+		n := rapid.IntRange(0, 50).Draw(t, "n").(int)
+		for i := 0; i < n; i++ {
+			k := genKey().Draw(t, "k").(string)
+			switch rapid.IntRange(0, 4).Draw(t, "op").(int) {
+			case 0, 1:
+				v := rapid.IntRange(0, 100).Draw(t, "v").(int)
+				tr = tr.Set(k, v)
+				model[k] = v
+			case 2:
+				tr = tr.Remove(k)
+				delete(model, k)
+			case 3:
+				pre := tr.WithPrefix(k)
+				require.NoError(t, pre.t.root.checkInvariant())
+				prefixModel := make(map[string]int)
+				for mk, mv := range model {
+					if strings.HasPrefix(mk, k) {
+						prefixModel[mk] = mv
+					}
+				}
+				checkModel(t, pre, prefixModel)
+			case 4:
+				e, ok := tr.LongestPrefixOf(k)
+				expectedOk := false
+				for j := len(k); j >= 0; j-- {
+					if v, found := model[k[:j]]; found {
+						require.True(t, ok)
+						require.Equal(t, dict.E(k[:j], v), e)
+						expectedOk = true
+						break
+					}
+				}
+				require.Equal(t, expectedOk, ok)
+			}
+			v, ok := tr.Get(k)
+			mv, mok := model[k]
+			require.Equal(t, mok, ok)
+			require.Equal(t, mv, v)
+			checkModel(t, tr, model)
+
+			copied := make(map[string]int)
+			for mk, mv := range model {
+				copied[mk] = mv
+			}
+			versions = append(versions, tr)
+			models = append(models, copied)
+		}
+		// old versions are not affected by later updates
+		for i := range versions {
+			checkModel(t, versions[i], models[i])
+		}
+	})
+}
+
+func TestSliceTrieRemoveUnchanged(t *testing.T) {
+	tr := New(dict.E("ab", 1), dict.E("abc", 2))
+	for _, k := range []string{"", "a", "abd", "abcd", "x"} {
+		require.Equal(t, tr.t.root, tr.Remove(k).t.root, "removing %q", k)
+	}
+}
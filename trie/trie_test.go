@@ -0,0 +1,62 @@
+package trie_test
+
+import (
+	"fmt"
+
+	"github.com/peterzeller/go-fun/dict"
+	"github.com/peterzeller/go-fun/hash"
+	"github.com/peterzeller/go-fun/iterable"
+	"github.com/peterzeller/go-fun/trie"
+)
+
+func ExampleTrie_WithPrefix() {
+	t := trie.New(
+		dict.E("go", 1),
+		dict.E("gopher", 2),
+		dict.E("golang", 3),
+		dict.E("rust", 4))
+	fmt.Printf("%v\n", t.WithPrefix("go"))
+	fmt.Printf("%v\n", iterable.ToSlice(t.WithPrefix("gop").Keys()))
+	// output:
+	// [go -> 1, golang -> 3, gopher -> 2]
+	// [gopher]
+}
+
+func ExampleTrie_LongestPrefixOf() {
+	routes := trie.New(
+		dict.E("/", "root"),
+		dict.E("/api", "api"),
+		dict.E("/api/users", "users"))
+	e, ok := routes.LongestPrefixOf("/api/user/42")
+	fmt.Printf("%v %v\n", e, ok)
+	_, ok = routes.LongestPrefixOf("index.html")
+	fmt.Printf("%v\n", ok)
+	// output:
+	// /api -> api true
+	// false
+}
+
+func ExampleTrie_Set() {
+	var t0 trie.Trie[int]
+	t1 := t0.Set("b", 1).Set("a", 2)
+	t2 := t1.Set("ab", 3).Remove("b")
+	fmt.Printf("t0 = %v\n", t0)
+	fmt.Printf("t1 = %v\n", t1)
+	fmt.Printf("t2 = %v\n", t2)
+	// output:
+	// t0 = []
+	// t1 = [a -> 2, b -> 1]
+	// t2 = [a -> 2, ab -> 3]
+}
+
+func ExampleSliceTrie() {
+	t := trie.NewSlice[int, string](hash.Num[int](),
+		dict.E([]int{1, 2}, "a"),
+		dict.E([]int{1, 2, 3}, "b"),
+		dict.E([]int{2}, "c"))
+	fmt.Printf("%v\n", iterable.ToSlice(t.Sorted(func(a, b int) bool { return a < b })))
+	fmt.Printf("%v\n", t.WithPrefix([]int{1}).Size())
+	// output:
+	// [[1 2] -> a [1 2 3] -> b [2] -> c]
+	// 2
+}